@@ -12,6 +12,7 @@ type GlobalConfig struct {
 	accountsFilePath string
 	habitsFilePath   string
 	todoFilePath     string
+	friendsFilePath  string
 }
 
 var globalConfig GlobalConfig
@@ -42,6 +43,10 @@ func GetGlobalConfig() *GlobalConfig {
 		if todoFilePath == "" {
 			todoFilePath = "todo.json"
 		}
+		friendsFilePath := os.Getenv("FRIENDS_FILE")
+		if friendsFilePath == "" {
+			friendsFilePath = "friends.json"
+		}
 
 		globalConfig = GlobalConfig{
 			cached:           true,
@@ -52,6 +57,7 @@ func GetGlobalConfig() *GlobalConfig {
 			accountsFilePath: accountsFilePath,
 			habitsFilePath:   habitsFilePath,
 			todoFilePath:     todoFilePath,
+			friendsFilePath:  friendsFilePath,
 		}
 	}
 
@@ -6,8 +6,10 @@ import (
 
 	"github.com/Joshua-Hwang/habits2share/pkg/auth"
 	"github.com/Joshua-Hwang/habits2share/pkg/auth_file"
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_file"
+	"github.com/Joshua-Hwang/habits2share/pkg/live"
 	"github.com/Joshua-Hwang/habits2share/pkg/todo"
 )
 
@@ -15,21 +17,66 @@ type key string
 type UserIdType string
 
 type HabitAppInterface interface {
+	AcceptInvitation(id string) error
 	ArchiveHabit(id string) error
+	CancelInvitation(id string) error
 	ChangeDescription(id string, newDescription string) error
+	ChangeExcuseLimit(id string, excusesPerMonth int) error
 	ChangeFrequency(id string, newFrequency int) error
+	ChangeGroupMode(id string, mode string) error
 	ChangeName(id string, newName string) error
-	CreateActivity(habitId string, logged habit_share.Time, status string) (string, error)
-	CreateHabit(name string, frequency int) (string, error)
+	ChangePeriod(id string, newPeriod habit_share.Period, newFrequency int) error
+	ChangeScoring(id string, scoring string) error
+	ChangeSchedule(id string, weekdays []time.Weekday) error
+	ChangeSettings(timezone string, dayStartHour int) error
+	ChangeTarget(id string, unit string, target float64, minimum float64) error
+	CreateActivity(habitId string, logged habit_share.Time, status string, amount float64) (string, error)
+	CreateChallenge(name string, description string, start habit_share.Time, end habit_share.Time, frequency int, period habit_share.Period, participants []string) (string, error)
+	CreateGroupHabit(name string, frequency int, period habit_share.Period, members []string, mode string) (string, error)
+	CreateHabit(name string, frequency int, period habit_share.Period) (string, error)
+	CreatePublicLink(habitId string) (habit_share.PublicLink, error)
+	CreateVacation(start habit_share.Time, end habit_share.Time) (string, error)
+	DeclineInvitation(id string) error
+	EditComment(habitId string, id string, body string) error
 	DeleteActivity(habitId string, id string) error
+	DeleteComment(habitId string, id string) error
 	DeleteHabit(id string) error
+	DeleteNotification(id string) error
+	DeleteVacation(id string) error
 	GetActivities(habitId string, after habit_share.Time, before habit_share.Time, limit int) (activities []habit_share.Activity, hasMore bool, err error)
+	GetChallenge(id string) (habit_share.Challenge, error)
+	GetComments(habitId string, before time.Time, limit int) (comments []habit_share.Comment, hasMore bool, err error)
+	GetFeed(cursor int64, limit int) (events []habit_share.Event, nextCursor int64, err error)
 	GetHabit(id string) (habit_share.Habit, error)
+	GetLeaderboard(metric string, days int) ([]habit_share.LeaderboardEntry, error)
+	GetMemberStatuses(habitId string) ([]habit_share.MemberStatus, error)
+	GetMyChallenges() ([]habit_share.Challenge, error)
 	GetMyHabits(limit int, archived bool) ([]habit_share.Habit, error)
+	GetMyInvitations() ([]habit_share.Invitation, error)
+	GetMySettings() (habit_share.UserSettings, error)
+	GetMyVacations() ([]habit_share.Vacation, error)
+	GetMutedHabits(limit int) ([]habit_share.Habit, error)
+	GetNotifications(limit int, unread bool) ([]habit_share.Notification, error)
+	GetOwnerVacation(habitId string) (habit_share.Vacation, bool, error)
+	GetPublicLinks(habitId string) ([]habit_share.PublicLink, error)
+	GetReactions(habitId string) ([]habit_share.Reaction, error)
 	GetScore(habitId string) (int, error)
+	GetStats(habitId string) (habit_share.Stats, error)
+	GetSentInvitations() ([]habit_share.Invitation, error)
 	GetSharedHabits(limit int) ([]habit_share.Habit, error)
-	ShareHabit(habitId string, friend string) error
+	HabitToday(habitId string) (habit_share.Time, error)
+	InviteToHabit(habitId string, to string, permission habit_share.Permission) (string, error)
+	JoinChallenge(id string) (string, error)
+	LeaveHabit(habitId string) error
+	MarkNotificationRead(id string) error
+	MuteHabit(habitId string) error
+	PostComment(habitId string, body string) (string, error)
+	React(habitId string, activityId string, emoji string) error
+	RevokePublicLink(habitId string, token string) error
+	ShareHabit(habitId string, friend string, permission habit_share.Permission, expires *time.Time) error
 	UnShareHabit(habitId string, friend string) error
+	UnmuteHabit(habitId string) error
+	Unreact(habitId string, activityId string) error
 }
 
 type TodoAppInterface interface {
@@ -42,6 +89,20 @@ type TodoAppInterface interface {
 	GetTodo(todoId string) (todo.Todo, error)
 }
 
+type FriendsAppInterface interface {
+	AcceptRequest(from string) error
+	Block(blocked string) error
+	CancelRequest(to string) error
+	DeclineRequest(from string) error
+	GetBlocked() ([]string, error)
+	GetFriends() ([]string, error)
+	GetIncomingRequests() ([]friends.FriendRequest, error)
+	GetOutgoingRequests() ([]friends.FriendRequest, error)
+	RemoveFriend(friend string) error
+	SendRequest(to string) error
+	Unblock(blocked string) error
+}
+
 // Contains app scoped dependencies
 type Server struct {
 	// Nothing else is in this struct. Dependencies is here purely for semantics
@@ -53,7 +114,14 @@ type GlobalDependencies struct {
 	AuthDatabase   *auth_file.AuthDatabaseFile
 	TokenParser    *auth.TokenParserGoogle
 	HabitsDatabase *habit_share_file.HabitShareFile
-	TodoDatabase   todo.TodoDatabase
+	TodoDatabase    todo.TodoDatabase
+	FriendsDatabase friends.FriendsDatabase
+	Scores          *habit_share.ScoreCache
+	Live            *live.Broker
+	// Tests set these to hand every request mocks instead of the apps built
+	// from the databases above
+	HabitAppOverride   HabitAppInterface
+	FriendsAppOverride FriendsAppInterface
 }
 
 // TODO probably worth splitting, not very performant
@@ -63,6 +131,7 @@ type RequestDependencies struct {
 	AuthService *auth.AuthService
 	HabitApp    HabitAppInterface
 	TodoApp     TodoAppInterface
+	FriendsApp  FriendsAppInterface
 }
 
 func (s Server) BuildRequestDependenciesOrReject(w http.ResponseWriter, r *http.Request) (*RequestDependencies, error) {
@@ -70,14 +139,22 @@ func (s Server) BuildRequestDependenciesOrReject(w http.ResponseWriter, r *http.
 	if err != nil {
 		return nil, err
 	}
-	habitApp := s.BuildHabitApp(authService)
+	var habitApp HabitAppInterface = s.BuildHabitApp(authService)
+	if s.HabitAppOverride != nil {
+		habitApp = s.HabitAppOverride
+	}
 	todoApp := s.BuildTodoApp(authService)
+	var friendsApp FriendsAppInterface = s.BuildFriendsApp(authService)
+	if s.FriendsAppOverride != nil {
+		friendsApp = s.FriendsAppOverride
+	}
 
 	requestDependencies := RequestDependencies{
 		GlobalDependencies: s.GlobalDependencies,
 		AuthService:        authService,
 		HabitApp:           habitApp,
 		TodoApp:            todoApp,
+		FriendsApp:         friendsApp,
 	}
 
 	return &requestDependencies, nil
@@ -88,11 +165,38 @@ func (s Server) BuildRequestDependenciesOrReject(w http.ResponseWriter, r *http.
 func (s Server) BuildHabitApp(
 	authService habit_share.AuthInterface,
 ) *habit_share.App {
-	return &habit_share.App{Db: s.HabitsDatabase, Auth: authService}
+	return &habit_share.App{
+		Db:      s.HabitsDatabase,
+		Auth:    authService,
+		Scores:  s.Scores,
+		Friends: s.FriendsDatabase,
+		Live:    s.Live,
+	}
+}
+
+// BuildPublicHabitApp has no current user so only works for what doesn't
+// need one, like public links and finishing challenges
+func (s Server) BuildPublicHabitApp() *habit_share.App {
+	return &habit_share.App{
+		Db:      s.HabitsDatabase,
+		Scores:  s.Scores,
+		Friends: s.FriendsDatabase,
+		Live:    s.Live,
+	}
 }
 
 func (s Server) BuildTodoApp(
 	authService todo.AuthInterface,
 ) *todo.App {
-	return &todo.App{Db: s.TodoDatabase, Auth: authService}
+	return &todo.App{Db: s.TodoDatabase, Auth: authService, Live: s.Live}
+}
+
+func (s Server) BuildFriendsApp(
+	authService friends.AuthInterface,
+) *friends.App {
+	return &friends.App{
+		Db:     s.FriendsDatabase,
+		Auth:   authService,
+		Habits: s.BuildHabitApp(authService),
+	}
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/auth_file"
+	"github.com/Joshua-Hwang/habits2share/pkg/live"
+	"github.com/google/uuid"
+)
+
+// newTestServer returns a Server whose apps are the mocks given along with
+// the session cookie of testUser. friend is the only other account.
+func newTestServer(t *testing.T, habitApp HabitAppInterface, friendsApp FriendsAppInterface) (Server, *http.Cookie) {
+	tempDir := t.TempDir()
+	accounts := `[{"Id": "testUser", "Email": "test@user.com"}, {"Id": "friend", "Email": "friend@user.com"}]`
+	if err := os.WriteFile(tempDir+"/accounts.json", []byte(accounts), 0600); err != nil {
+		t.Fatal("failed to write accounts:", err)
+	}
+	authDatabase := &auth_file.AuthDatabaseFile{
+		SessionsFilepath: tempDir + "/sessions.csv",
+		SessionsFileLock: &sync.RWMutex{},
+		AccountsFilepath: tempDir + "/accounts.json",
+	}
+	sessionId := uuid.NewString()
+	if err := authDatabase.AddSession(context.Background(), sessionId, "testUser"); err != nil {
+		t.Fatal("failed to add session:", err)
+	}
+
+	server := Server{
+		GlobalDependencies{
+			AuthDatabase:       authDatabase,
+			Live:               live.NewBroker(4),
+			HabitAppOverride:   habitApp,
+			FriendsAppOverride: friendsApp,
+		},
+	}
+	return server, &http.Cookie{Name: sessionCookieName, Value: sessionId}
+}
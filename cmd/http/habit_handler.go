@@ -22,11 +22,19 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 			// optimised endpoint
 			app := reqDeps.HabitApp
 
+			today, err := app.HabitToday(habit.Id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong getting today's date")
+				log.Printf("Something has gone wrong getting today's date: %v", err)
+				return
+			}
+
 			// taken from the activities endpoint
 			activities, _, err := app.GetActivities(habit.Id,
-				habit_share.Time{Time: time.Now().AddDate(0, 0, -7)},
-				habit_share.Time{Time: time.Now().AddDate(0, 0, 1)},
-				7,
+				habit_share.Time{Time: today.AddDate(0, 0, -7)},
+				habit_share.Time{Time: today.AddDate(0, 0, 1)},
+				defaultActivitiesLimit(habit),
 			)
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
@@ -43,11 +51,42 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				return
 			}
 
+			reactions, err := app.GetReactions(habit.Id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong getting reactions")
+				log.Printf("Something has gone wrong getting reactions: %v", err)
+				return
+			}
+
+			var members []habit_share.MemberStatus
+			if habit.IsGroup() {
+				members, err = app.GetMemberStatuses(habit.Id)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Something has gone wrong getting member statuses")
+					log.Printf("Something has gone wrong getting member statuses: %v", err)
+					return
+				}
+			}
+
 			response := struct {
 				*habit_share.Habit
-				Activities []habit_share.Activity
-				Score      int
-			}{Habit: habit, Activities: activities, Score: score}
+				Activities    []habit_share.Activity
+				Score         int
+				ScoreStrategy string
+				// how each member of a group habit is doing this period
+				MemberStatuses []habit_share.MemberStatus `json:",omitempty"`
+				// across all the habit's activities
+				ReactionCount int
+			}{
+				Habit:          habit,
+				Activities:     activities,
+				Score:          score,
+				ScoreStrategy:  habit.ScoringName(),
+				MemberStatuses: members,
+				ReactionCount:  len(reactions),
+			}
 
 			bytes, err := json.Marshal(response)
 			if err != nil {
@@ -73,6 +112,19 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				Name        string
 				Frequency   int
 				Description string
+				// Frequency is changed together with Period when both are present
+				Period *habit_share.Period
+				// Unit and Minimum are changed together with Target
+				Unit            string
+				Target          *float64
+				Minimum         float64
+				ExcusesPerMonth int
+				// one of STREAK, STRENGTH or COUNT
+				Scoring string
+				// ALL or ANY, only for group habits
+				GroupMode string
+				// nil leaves the schedule alone, an empty list clears it
+				Weekdays *[]time.Weekday
 			}{}
 			decoder := json.NewDecoder(r.Body)
 			decoder.DisallowUnknownFields()
@@ -106,7 +158,27 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				return
 			}
 
-			if updatePayload.Frequency != 0 {
+			if updatePayload.Weekdays != nil {
+				err = app.ChangeSchedule(habit.Id, *updatePayload.Weekdays)
+			}
+			if err != nil {
+				if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, Weekdays were invalid")
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to change schedule")
+					log.Printf("Something has gone wrong changing schedule: %v", err)
+				}
+				return
+			}
+
+			if updatePayload.Period != nil {
+				err = app.ChangePeriod(habit.Id, *updatePayload.Period, updatePayload.Frequency)
+			} else if updatePayload.Frequency != 0 {
 				err = app.ChangeFrequency(habit.Id, updatePayload.Frequency)
 			}
 			if err != nil {
@@ -124,6 +196,78 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				return
 			}
 
+			if updatePayload.Target != nil {
+				err = app.ChangeTarget(habit.Id, updatePayload.Unit, *updatePayload.Target, updatePayload.Minimum)
+			}
+			if err != nil {
+				if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, Target or Minimum was invalid")
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to change target")
+					log.Printf("Something has gone wrong changing target: %v", err)
+				}
+				return
+			}
+
+			if updatePayload.ExcusesPerMonth != 0 {
+				err = app.ChangeExcuseLimit(habit.Id, updatePayload.ExcusesPerMonth)
+			}
+			if err != nil {
+				if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, ExcusesPerMonth must be between 1 and 31")
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to change excuse limit")
+					log.Printf("Something has gone wrong changing excuse limit: %v", err)
+				}
+				return
+			}
+
+			if updatePayload.Scoring != "" {
+				err = app.ChangeScoring(habit.Id, updatePayload.Scoring)
+			}
+			if err != nil {
+				if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, Scoring must be one of STREAK, STRENGTH or COUNT")
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to change scoring")
+					log.Printf("Something has gone wrong changing scoring: %v", err)
+				}
+				return
+			}
+
+			if updatePayload.GroupMode != "" {
+				err = app.ChangeGroupMode(habit.Id, updatePayload.GroupMode)
+			}
+			if err != nil {
+				if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, GroupMode must be one of ALL or ANY and the habit must be a group habit")
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to change group mode")
+					log.Printf("Something has gone wrong changing group mode: %v", err)
+				}
+				return
+			}
+
 			if updatePayload.Description != "" {
 				err = app.ChangeDescription(habit.Id, updatePayload.Description)
 			}
@@ -202,6 +346,20 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 			fmt.Fprintf(w, "%d", habit.Frequency)
 		},
 	})
+	mux.RegisterHandlers("/period", map[string]http.HandlerFunc{
+		"GET": func(w http.ResponseWriter, r *http.Request) {
+			bytes, err := json.Marshal(habit.Period)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong writing period to json")
+				log.Printf("Something has gone wrong writing period to json: %v", err)
+				return
+			}
+
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, "%s", string(bytes))
+		},
+	})
 	mux.RegisterHandlers("/score", map[string]http.HandlerFunc{
 		"GET": func(w http.ResponseWriter, r *http.Request) {
 			app := reqDeps.HabitApp
@@ -216,17 +374,328 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 			fmt.Fprintf(w, "%d", score)
 		},
 	})
+	mux.RegisterHandlers("/stats", map[string]http.HandlerFunc{
+		"GET": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			stats, err := app.GetStats(habit.Id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Failed to calculate stats")
+				log.Printf("Failed to calculate stats: %v", err)
+				return
+			}
+
+			bytes, err := json.Marshal(stats)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong writing stats to json")
+				log.Printf("Something has gone wrong writing stats to json: %v", err)
+				return
+			}
+
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, "%s", string(bytes))
+		},
+	})
+	// POST to /habit/:habitId/reactions to react to an activity
+	mux.RegisterHandlers("/reactions", map[string]http.HandlerFunc{
+		"POST": func(w http.ResponseWriter, r *http.Request) {
+			var err error
+			if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				fmt.Fprintf(w, "Content Type is not application/json")
+				return
+			}
+
+			app := reqDeps.HabitApp
+
+			newReaction := struct {
+				ActivityId string
+				Emoji      string
+			}{}
+			decoder := json.NewDecoder(r.Body)
+			decoder.DisallowUnknownFields()
+			err = decoder.Decode(&newReaction)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				var unmarshalErr *json.UnmarshalTypeError
+				if errors.As(err, &unmarshalErr) {
+					fmt.Fprintf(w, "Bad Request. Wrong Type provided for field: %s", unmarshalErr.Field)
+				} else {
+					fmt.Fprintf(w, "Bad Request: %s", err)
+				}
+				return
+			}
+
+			err = app.React(habit.Id, newReaction.ActivityId, newReaction.Emoji)
+			if err != nil {
+				if err == habit_share.ActivityNotFoundError {
+					http.NotFound(w, r)
+				} else if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, Emoji must be given and short")
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to react")
+					log.Printf("Something has gone wrong reacting: %v", err)
+				}
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+		},
+	})
+	// DELETE to /habit/:habitId/reactions/:activityId removes your reaction
+	mux.RegisterHandlers("/reactions/", map[string]http.HandlerFunc{
+		"DELETE": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			activityId := strings.TrimPrefix(r.URL.EscapedPath(), "/reactions/")
+			if activityId == "" || strings.Contains(activityId, "/") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "Activity id invalid")
+				return
+			}
+
+			err := app.Unreact(habit.Id, activityId)
+			if err != nil {
+				if err == habit_share.ReactionNotFoundError {
+					http.NotFound(w, r)
+				} else if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintf(w, "Failed to remove reaction")
+					log.Printf("Something has gone wrong removing reaction: %v", err)
+				}
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	// GET to /habit/:habitId/comments?before=...&limit=... pages back through the
+	// thread from the newest comment
+	mux.RegisterHandlers("/comments", map[string]http.HandlerFunc{
+		"GET": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			before := time.Now()
+			if beforeString := r.URL.Query().Get("before"); beforeString != "" {
+				var err error
+				before, err = time.Parse(time.RFC3339Nano, beforeString)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Before query is in incorrect, must be in RFC 3339 format")
+					return
+				}
+			}
+
+			limitString := r.URL.Query().Get("limit")
+			if limitString == "" {
+				limitString = "20"
+			}
+			limit, err := strconv.Atoi(limitString)
+			if err != nil || limit < 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "Limit query is in incorrect, must be an integer")
+				return
+			}
+
+			comments, hasMore, err := app.GetComments(habit.Id, before, limit)
+			if err != nil {
+				if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+					return
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong getting comments")
+				log.Printf("Something has gone wrong getting comments: %v", err)
+				return
+			}
+
+			response := struct {
+				Comments []habit_share.Comment
+				HasMore  bool
+			}{Comments: comments, HasMore: hasMore}
+
+			bytes, err := json.Marshal(response)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong writing comments to json")
+				log.Printf("Something has gone wrong writing comments to json: %v", err)
+				return
+			}
+
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, "%s", string(bytes))
+		},
+		"POST": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			body, ok := decodeCommentBody(w, r)
+			if !ok {
+				return
+			}
+
+			commentId, err := app.PostComment(habit.Id, body)
+			if err != nil {
+				writeCommentError(w, r, err, "posting comment")
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, commentId)
+		},
+	})
+	// POST to /habit/:habitId/comments/:commentId edits and DELETE removes it
+	mux.RegisterHandlers("/comments/", map[string]http.HandlerFunc{
+		"POST": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			commentId := strings.TrimPrefix(r.URL.EscapedPath(), "/comments/")
+			if commentId == "" || strings.Contains(commentId, "/") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "Comment id invalid")
+				return
+			}
+			body, ok := decodeCommentBody(w, r)
+			if !ok {
+				return
+			}
+
+			err := app.EditComment(habit.Id, commentId, body)
+			if err != nil {
+				writeCommentError(w, r, err, "editing comment")
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"DELETE": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			commentId := strings.TrimPrefix(r.URL.EscapedPath(), "/comments/")
+			if commentId == "" || strings.Contains(commentId, "/") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "Comment id invalid")
+				return
+			}
+
+			err := app.DeleteComment(habit.Id, commentId)
+			if err != nil {
+				writeCommentError(w, r, err, "deleting comment")
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	// public links let anyone see the habit at /public/habit/:token
+	mux.RegisterHandlers("/links", map[string]http.HandlerFunc{
+		"GET": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			links, err := app.GetPublicLinks(habit.Id)
+			if err != nil {
+				if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+					return
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Failed to get public links")
+				log.Printf("Something has gone wrong getting public links: %v", err)
+				return
+			}
+
+			bytes, err := json.Marshal(links)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong writing public links to json")
+				log.Printf("Something has gone wrong writing public links to json: %v", err)
+				return
+			}
+
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, "%s", string(bytes))
+		},
+		"POST": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			link, err := app.CreatePublicLink(habit.Id)
+			if err != nil {
+				if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+					return
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Failed to create public link")
+				log.Printf("Something has gone wrong creating public link: %v", err)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, link.Token)
+		},
+	})
+	mux.RegisterHandlers("/links/", map[string]http.HandlerFunc{
+		"DELETE": func(w http.ResponseWriter, r *http.Request) {
+			app := reqDeps.HabitApp
+
+			token := strings.TrimPrefix(r.URL.EscapedPath(), "/links/")
+			if token == "" || strings.Contains(token, "/") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "Token invalid")
+				return
+			}
+
+			err := app.RevokePublicLink(habit.Id, token)
+			if err != nil {
+				if err == habit_share.PublicLinkNotFoundError {
+					http.NotFound(w, r)
+					return
+				}
+				if errors.Is(err, habit_share.PermissionDeniedError) {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprintf(w, "You do not have permissions for this habit")
+					return
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Failed to revoke public link")
+				log.Printf("Something has gone wrong revoking public link: %v", err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
 	// POST to /habit/:habitId/activities with status in body to register an activity
 	// GET to /habit/:habitId/activities?limit=...&order=... works on the pagination of activities
 	mux.RegisterHandlers("/activities", map[string]http.HandlerFunc{
 		"GET": func(w http.ResponseWriter, r *http.Request) {
 			app := reqDeps.HabitApp
 
+			// today for the owner of the habit as they're the one logging activities
+			today, err := app.HabitToday(habit.Id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong getting today's date")
+				log.Printf("Something has gone wrong getting today's date: %v", err)
+				return
+			}
+
 			beforeString := r.URL.Query().Get("before")
 			if beforeString == "" {
 				// before is not inclusive but we'd like today to be displayed.
-				// A second day has to be added because timezones
-				beforeString = time.Now().AddDate(0, 0, 2).Format(habit_share.DateFormat)
+				beforeString = today.AddDate(0, 0, 1).Format(habit_share.DateFormat)
 			}
 			before, err := time.Parse(habit_share.DateFormat, beforeString)
 			if err != nil {
@@ -236,8 +705,7 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 
 			afterString := r.URL.Query().Get("after")
 			if afterString == "" {
-				// A day is removed because timezones
-				afterString = time.Now().AddDate(0, 0, -8).Format(habit_share.DateFormat)
+				afterString = today.AddDate(0, 0, -7).Format(habit_share.DateFormat)
 			}
 
 			after, err := time.Parse(habit_share.DateFormat, afterString)
@@ -246,14 +714,13 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				fmt.Fprintf(w, "After query is in incorrect, must be in YYYY-mm-dd format")
 			}
 
-			limitString := r.URL.Query().Get("limit")
-			if limitString == "" {
-				limitString = "7"
-			}
-			limit, err := strconv.Atoi(limitString)
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprintf(w, "Limit query is in incorrect, must be an integer")
+			limit := defaultActivitiesLimit(habit)
+			if limitString := r.URL.Query().Get("limit"); limitString != "" {
+				limit, err = strconv.Atoi(limitString)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Limit query is in incorrect, must be an integer")
+				}
 			}
 
 			if before.Before(after) {
@@ -274,11 +741,31 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				log.Printf("Something has gone wrong getting activities: %v", err)
 				return
 			}
+			reactions, err := app.GetReactions(habit.Id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Something has gone wrong getting reactions")
+				log.Printf("Something has gone wrong getting reactions: %v", err)
+				return
+			}
+			// only those on the activities returned
+			activityReactions := make(map[string][]habit_share.Reaction, len(activities))
+			for _, activity := range activities {
+				activityReactions[activity.Id] = make([]habit_share.Reaction, 0)
+			}
+			for _, reaction := range reactions {
+				if _, ok := activityReactions[reaction.ActivityId]; ok {
+					activityReactions[reaction.ActivityId] = append(activityReactions[reaction.ActivityId], reaction)
+				}
+			}
+
 			// TODO change from RFC3339 to own date format
 			response := struct {
 				Activities []habit_share.Activity
 				HasMore    bool
-			}{Activities: activities, HasMore: hasMore}
+				// keyed by activity id
+				Reactions map[string][]habit_share.Reaction
+			}{Activities: activities, HasMore: hasMore, Reactions: activityReactions}
 
 			bytes, err := json.Marshal(response)
 			if err != nil {
@@ -304,6 +791,8 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 			newActivity := struct {
 				Logged string
 				Status string
+				// quantitative habits derive Status from Amount
+				Amount float64
 			}{}
 			decoder := json.NewDecoder(r.Body)
 			decoder.DisallowUnknownFields()
@@ -326,16 +815,21 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 				return
 			}
 
-			if newActivity.Status == "NOT_DONE" {
+			// members of group habits log NOT_DONE themselves as the activity to
+			// delete is theirs rather than the habit's
+			if newActivity.Status == "NOT_DONE" && !habit.IsGroup() {
 				err = app.DeleteActivity(habit.Id, habit_share_file.ConstructActivityId(habit.Id, habit_share.Time{Time: parsedLog}))
 			} else {
-				_, err = app.CreateActivity(habit.Id, habit_share.Time{Time: parsedLog}, newActivity.Status)
+				_, err = app.CreateActivity(habit.Id, habit_share.Time{Time: parsedLog}, newActivity.Status, newActivity.Amount)
 			}
 
 			if err != nil {
-				if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+				if errors.Is(err, habit_share.ExcuseLimitReachedError) {
 					w.WriteHeader(http.StatusBadRequest)
-					fmt.Fprintf(w, "Bad Request, Status was not one of the defined enum values")
+					fmt.Fprintf(w, "Bad Request, no excuses left for this month")
+				} else if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "Bad Request, Status was not one of the defined enum values or Amount was invalid")
 				} else if errors.Is(err, habit_share.PermissionDeniedError) {
 					w.WriteHeader(http.StatusForbidden)
 					fmt.Fprintf(w, "You do not have permissions for this habit")
@@ -353,3 +847,57 @@ func (reqDeps RequestDependencies) BuildHabitHandler(habit *habit_share.Habit) h
 
 	return mux
 }
+
+// decodeCommentBody reads {"Body": ...} writing the error response if it can't
+func decodeCommentBody(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "Content Type is not application/json")
+		return "", false
+	}
+
+	payload := struct {
+		Body string
+	}{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&payload)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		var unmarshalErr *json.UnmarshalTypeError
+		if errors.As(err, &unmarshalErr) {
+			fmt.Fprintf(w, "Bad Request. Wrong Type provided for field: %s", unmarshalErr.Field)
+		} else {
+			fmt.Fprintf(w, "Bad Request: %s", err)
+		}
+		return "", false
+	}
+
+	return payload.Body, true
+}
+
+// defaultActivitiesLimit is a week of activities. Group habits have an
+// activity a day for each member.
+func defaultActivitiesLimit(habit *habit_share.Habit) int {
+	limit := 7
+	if habit.IsGroup() {
+		limit *= len(habit.Members)
+	}
+	return limit
+}
+
+func writeCommentError(w http.ResponseWriter, r *http.Request, err error, doing string) {
+	if err == habit_share.CommentNotFoundError {
+		http.NotFound(w, r)
+	} else if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Bad Request, Body must be given and at most 2000 characters")
+	} else if errors.Is(err, habit_share.PermissionDeniedError) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, "You do not have permissions for this comment")
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Failed %s", doing)
+		log.Printf("Something has gone wrong %s: %v", doing, err)
+	}
+}
@@ -21,17 +21,23 @@ func TestHandleHabitEndpoints(t *testing.T) {
 		habit := habit_share.Habit{
 			Id:          "mock id",
 			Owner:       "mock owner",
-			SharedWith:  map[string]struct{}{},
+			SharedWith:  map[string]habit_share.Permission{},
 			Name:        "mock name",
 			Description: "mock desc",
 			Frequency:   4,
 			Archived:    false,
 		}
+		habit.ScheduleHistory = []habit_share.ScheduleChange{
+			{Frequency: 3},
+			{From: habit_share.Time{Time: time.Now()}, Frequency: 4},
+		}
 		habitHandler := reqDeps.BuildHabitHandler(&habit)
 
+		habitApp.EXPECT().HabitToday("mock id").Return(habit_share.Time{Time: time.Now()}, nil)
 		habitApp.EXPECT().GetActivities("mock id", gomock.Any(), gomock.Any(), 7).
 			Return([]habit_share.Activity{}, false, nil)
 		habitApp.EXPECT().GetScore("mock id").Return(20, nil)
+		habitApp.EXPECT().GetReactions("mock id").Return([]habit_share.Reaction{{ActivityId: "fake id 1"}}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		w := httptest.NewRecorder()
@@ -41,6 +47,9 @@ func TestHandleHabitEndpoints(t *testing.T) {
 
 		resPayload := struct {
 			*habit_share.Habit
+			Score         int
+			ScoreStrategy string
+			ReactionCount int
 		}{}
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resPayload)
@@ -50,6 +59,15 @@ func TestHandleHabitEndpoints(t *testing.T) {
 		if resPayload.Id != habit.Id || resPayload.Owner != habit.Owner || resPayload.Name != habit.Name {
 			t.Error("expected equality between", habit, "and", *resPayload.Habit)
 		}
+		if len(resPayload.ScheduleHistory) != 2 || resPayload.ScheduleHistory[0].Frequency != 3 {
+			t.Error("expected schedule history got", resPayload.ScheduleHistory)
+		}
+		if resPayload.Score != 20 || resPayload.ScoreStrategy != habit_share.ScoringStreak {
+			t.Error("expected score 20 with STREAK got", resPayload.Score, resPayload.ScoreStrategy)
+		}
+		if resPayload.ReactionCount != 1 {
+			t.Error("expected 1 reaction got", resPayload.ReactionCount)
+		}
 	})
 
 	t.Run("GET / returns activity info", func(t *testing.T) {
@@ -59,7 +77,7 @@ func TestHandleHabitEndpoints(t *testing.T) {
 		habit := habit_share.Habit{
 			Id:          "mock id",
 			Owner:       "mock owner",
-			SharedWith:  map[string]struct{}{},
+			SharedWith:  map[string]habit_share.Permission{},
 			Name:        "mock name",
 			Description: "mock desc",
 			Frequency:   4,
@@ -75,9 +93,11 @@ func TestHandleHabitEndpoints(t *testing.T) {
 			{Id: "fake id 3",
 				Logged: habit_share.Time{Time: time.Now().AddDate(0, 0, -1)}},
 		}
+		habitApp.EXPECT().HabitToday("mock id").Return(habit_share.Time{Time: time.Now()}, nil)
 		habitApp.EXPECT().GetActivities("mock id", gomock.Any(), gomock.Any(), 7).
 			Return(activities, false, nil)
 		habitApp.EXPECT().GetScore("mock id").Return(20, nil)
+		habitApp.EXPECT().GetReactions("mock id").Return([]habit_share.Reaction{{ActivityId: "fake id 1"}}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		w := httptest.NewRecorder()
@@ -99,6 +119,36 @@ func TestHandleHabitEndpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("GET /activities defaults to a week for each member of a group habit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{
+			Id:         "mock id",
+			Owner:      "mock owner",
+			Members:    []string{"mock member", "mock owner"},
+			SharedWith: map[string]habit_share.Permission{},
+			Name:       "mock name",
+			Frequency:  4,
+		}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().HabitToday("mock id").Return(habit_share.Time{Time: time.Now()}, nil)
+		habitApp.EXPECT().GetActivities("mock id", gomock.Any(), gomock.Any(), 14).
+			Return([]habit_share.Activity{}, false, nil)
+		habitApp.EXPECT().GetReactions("mock id").Return([]habit_share.Reaction{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/activities", nil)
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Error("expected status code to be", http.StatusOK, "got", res.StatusCode)
+		}
+	})
+
 	t.Run("POST / updates habit", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
@@ -106,7 +156,7 @@ func TestHandleHabitEndpoints(t *testing.T) {
 		habit := habit_share.Habit{
 			Id:          "mock id",
 			Owner:       "mock owner",
-			SharedWith:  map[string]struct{}{},
+			SharedWith:  map[string]habit_share.Permission{},
 			Name:        "mock name",
 			Description: "mock desc",
 			Frequency:   4,
@@ -127,4 +177,165 @@ func TestHandleHabitEndpoints(t *testing.T) {
 			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
 		}
 	})
+
+	t.Run("POST /activities passes amount through", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{
+			Id:         "mock id",
+			Owner:      "mock owner",
+			SharedWith: map[string]habit_share.Permission{},
+			Name:       "mock name",
+			Frequency:  4,
+			Unit:       "L",
+			Target:     2,
+		}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().CreateActivity("mock id", gomock.Any(), "", 2.5).Return("mock id_2022-01-01", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/activities", strings.NewReader("{\"Logged\": \"2022-01-01\", \"Amount\": 2.5}"))
+		req.Header.Add("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST /reactions reacts to the activity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{Id: "mock id", Owner: "mock owner", Frequency: 4}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().React("mock id", "mock id_2022-01-01", "🔥")
+
+		req := httptest.NewRequest(http.MethodPost, "/reactions", strings.NewReader("{\"ActivityId\": \"mock id_2022-01-01\", \"Emoji\": \"🔥\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST /reactions to an unknown activity is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{Id: "mock id", Owner: "mock owner", Frequency: 4}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().React("mock id", "missing", "🔥").Return(habit_share.ActivityNotFoundError)
+
+		req := httptest.NewRequest(http.MethodPost, "/reactions", strings.NewReader("{\"ActivityId\": \"missing\", \"Emoji\": \"🔥\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Error("expected status code to be", http.StatusNotFound, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("DELETE /reactions/:activityId removes the reaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{Id: "mock id", Owner: "mock owner", Frequency: 4}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().Unreact("mock id", "activity")
+
+		req := httptest.NewRequest(http.MethodDelete, "/reactions/activity", nil)
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNoContent {
+			t.Error("expected status code to be", http.StatusNoContent, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("GET /comments pages from before", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{Id: "mock id", Owner: "mock owner", Frequency: 4}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		before := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+		habitApp.EXPECT().GetComments("mock id", before, 2).
+			Return([]habit_share.Comment{{Id: "comment", Author: "friend", Body: "nice"}}, true, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/comments?before=2022-01-01T12:00:00Z&limit=2", nil)
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		resPayload := struct {
+			Comments []habit_share.Comment
+			HasMore  bool
+		}{}
+		if err := json.NewDecoder(res.Body).Decode(&resPayload); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if len(resPayload.Comments) != 1 || resPayload.Comments[0].Body != "nice" || !resPayload.HasMore {
+			t.Error("expected one comment and more to come got", resPayload)
+		}
+	})
+
+	t.Run("POST /comments posts the comment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{Id: "mock id", Owner: "mock owner", Frequency: 4}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().PostComment("mock id", "keep going").Return("comment", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/comments", strings.NewReader("{\"Body\": \"keep going\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("DELETE /comments/:commentId of someone else's is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		reqDeps := RequestDependencies{HabitApp: habitApp}
+		habit := habit_share.Habit{Id: "mock id", Owner: "mock owner", Frequency: 4}
+		habitHandler := reqDeps.BuildHabitHandler(&habit)
+
+		habitApp.EXPECT().DeleteComment("mock id", "comment").Return(habit_share.PermissionDeniedError)
+
+		req := httptest.NewRequest(http.MethodDelete, "/comments/comment", nil)
+		w := httptest.NewRecorder()
+		habitHandler.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusForbidden {
+			t.Error("expected status code to be", http.StatusForbidden, "got", res.StatusCode)
+		}
+	})
 }
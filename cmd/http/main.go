@@ -8,15 +8,105 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Joshua-Hwang/habits2share/pkg/auth"
 	"github.com/Joshua-Hwang/habits2share/pkg/auth_file"
+	"github.com/Joshua-Hwang/habits2share/pkg/friends_file"
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_file"
+	"github.com/Joshua-Hwang/habits2share/pkg/live"
 	"github.com/Joshua-Hwang/habits2share/pkg/todo"
 	"github.com/Joshua-Hwang/habits2share/pkg/todo_file"
 )
 
+// how often shares that have expired are removed
+const shareSweepInterval = time.Hour
+
+// sweepExpiredShares removes expired shares every interval. Expired shares
+// already stop working so this only keeps the file tidy.
+func sweepExpiredShares(db *habit_share_file.HabitShareFile, lock *sync.RWMutex, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		lock.Lock()
+		removed, err := db.RemoveExpiredShares(now)
+		lock.Unlock()
+		if err != nil {
+			log.Printf("Failed to remove expired shares: %v", err)
+			continue
+		}
+		if removed > 0 {
+			log.Printf("Removed %d expired shares", removed)
+		}
+	}
+}
+
+// how many of the latest live events are kept for clients that reconnect
+const liveReplayBuffer = 1024
+
+// how often challenges that are over get their final standings
+const challengeSweepInterval = time.Hour
+
+// finishEndedChallenges takes the final standings of challenges that are over
+// every interval. Challenges viewed before then are finished on the spot.
+func finishEndedChallenges(app *habit_share.App, lock *sync.RWMutex, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		lock.Lock()
+		finished, err := app.FinishEndedChallenges()
+		lock.Unlock()
+		if err != nil {
+			log.Printf("Failed to finish challenges: %v", err)
+			continue
+		}
+		if finished > 0 {
+			log.Printf("Finished %d challenges", finished)
+		}
+	}
+}
+
+// how often habits are checked for streaks at risk
+const streakSweepInterval = time.Hour
+
+// warnStreaksAtRisk warns about streaks that end unless the habit is done today
+// every interval
+func warnStreaksAtRisk(app *habit_share.App, lock *sync.RWMutex, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		lock.Lock()
+		warned, err := app.WarnStreaksAtRisk()
+		lock.Unlock()
+		if err != nil {
+			log.Printf("Failed to warn about streaks at risk: %v", err)
+			continue
+		}
+		if warned > 0 {
+			log.Printf("Warned %d users about streaks at risk", warned)
+		}
+	}
+}
+
+// lockRequests holds lock for reading while each request is handled so the
+// background jobs can hold it to change habits without racing requests.
+// Requests to the unlocked paths stay open too long to hold it.
+func lockRequests(lock *sync.RWMutex, next http.Handler, unlocked ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, path := range unlocked {
+			if r.URL.Path == path {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		lock.RLock()
+		defer lock.RUnlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
 func main() {
 	config := GetGlobalConfig()
 
@@ -44,21 +134,36 @@ func main() {
 		panic(err)
 	}
 
+	// the background jobs hold this while changing habits
+	habitsLock := &sync.RWMutex{}
+	go sweepExpiredShares(habitsDatabase, habitsLock, shareSweepInterval)
+
 	todoDatabase, err := todo_file.TodoFromFile(config.todoFilePath)
 	if err != nil {
 		panic(err)
 	}
 
+	friendsDatabase, err := friends_file.FriendsFromFile(config.friendsFilePath)
+	if err != nil {
+		panic(err)
+	}
+
 	// Hopefully it's sufficiently clear that this isn't all the dependencies
 	server := Server{
 		GlobalDependencies{
-			AuthDatabase:   authDatabase,
-			TokenParser:    tokenParser,
-			HabitsDatabase: habitsDatabase,
-			TodoDatabase:   todoDatabase,
+			AuthDatabase:    authDatabase,
+			TokenParser:     tokenParser,
+			HabitsDatabase:  habitsDatabase,
+			TodoDatabase:    todoDatabase,
+			FriendsDatabase: friendsDatabase,
+			Scores:          habit_share.NewScoreCache(),
+			Live:            live.NewBroker(liveReplayBuffer),
 		},
 	}
 
+	go finishEndedChallenges(server.BuildPublicHabitApp(), habitsLock, challengeSweepInterval)
+	go warnStreaksAtRisk(server.BuildPublicHabitApp(), habitsLock, streakSweepInterval)
+
 	mux := MuxWrapper{ServeMux: http.NewServeMux()}
 
 	mux.RegisterHandlers("/healthcheck", MethodHandlers{
@@ -89,10 +194,91 @@ func main() {
 		"POST": server.PostMyHabitsImport,
 	})
 
+	mux.RegisterHandlers("/my/settings", MethodHandlers{
+		"GET":  server.GetMySettings,
+		"POST": server.PostMySettings,
+	})
+
+	mux.RegisterHandlers("/my/vacations", MethodHandlers{
+		"GET":  server.GetMyVacations,
+		"POST": server.PostMyVacations,
+	})
+	mux.RegisterHandlers("/my/vacations/", MethodHandlers{
+		"DELETE": server.DeleteMyVacation,
+	})
+
+	mux.RegisterHandlers("/my/friends", MethodHandlers{
+		"GET": server.GetMyFriends,
+	})
+	mux.RegisterHandlers("/my/friends/", MethodHandlers{
+		"DELETE": server.DeleteMyFriend,
+	})
+	mux.RegisterHandlers("/my/friend-requests", MethodHandlers{
+		"GET":  server.GetMyFriendRequests,
+		"POST": server.PostMyFriendRequests,
+	})
+	mux.RegisterHandlers("/my/friend-requests/", MethodHandlers{
+		"POST":   server.PostMyFriendRequest,
+		"DELETE": server.DeleteMyFriendRequest,
+	})
+	mux.RegisterHandlers("/my/invitations", MethodHandlers{
+		"GET": server.GetMyInvitations,
+	})
+	mux.RegisterHandlers("/my/invitations/", MethodHandlers{
+		"POST": server.PostMyInvitation,
+	})
+	mux.RegisterHandlers("/my/invitations/sent", MethodHandlers{
+		"GET":  server.GetSentInvitations,
+		"POST": server.PostSentInvitations,
+	})
+	mux.RegisterHandlers("/my/invitations/sent/", MethodHandlers{
+		"DELETE": server.DeleteSentInvitation,
+	})
+	mux.RegisterHandlers("/my/challenges", MethodHandlers{
+		"GET":  server.GetMyChallenges,
+		"POST": server.PostMyChallenges,
+	})
+	mux.RegisterHandlers("/my/challenges/", MethodHandlers{
+		"GET":  server.GetMyChallenge,
+		"POST": server.PostMyChallenge,
+	})
+	mux.RegisterHandlers("/my/notifications", MethodHandlers{
+		"GET": server.GetMyNotifications,
+	})
+	mux.RegisterHandlers("/my/notifications/", MethodHandlers{
+		"POST":   server.PostMyNotification,
+		"DELETE": server.DeleteMyNotification,
+	})
+	mux.RegisterHandlers("/my/live", MethodHandlers{
+		"GET": server.GetMyLive,
+	})
+	mux.RegisterHandlers("/my/feed", MethodHandlers{
+		"GET": server.GetMyFeed,
+	})
+	mux.RegisterHandlers("/my/leaderboard", MethodHandlers{
+		"GET": server.GetMyLeaderboard,
+	})
+	mux.RegisterHandlers("/my/blocked", MethodHandlers{
+		"GET":  server.GetMyBlocked,
+		"POST": server.PostMyBlocked,
+	})
+	mux.RegisterHandlers("/my/blocked/", MethodHandlers{
+		"DELETE": server.DeleteMyBlocked,
+	})
+
 	// NOTE if performance is an issue consider creating an /all/habits
 	mux.RegisterHandlers("/shared/habits", MethodHandlers{
 		"GET": server.GetSharedHabits,
 	})
+	mux.RegisterHandlers("/shared/habits/", MethodHandlers{
+		"POST":   server.PostSharedHabit,
+		"DELETE": server.DeleteSharedHabit,
+	})
+
+	// anyone with the token can see the habit, no session is needed
+	mux.RegisterHandlers("/public/habit/", MethodHandlers{
+		"GET": server.GetPublicHabit,
+	})
 
 	// NOTE if performance is an issue return activities in same batch as habits
 	// How do you keep all this modular without burdening the client?
@@ -179,5 +365,5 @@ func main() {
 
 	log.Printf("Listening on port %s", config.port)
 	log.Printf("Process ID %d", os.Getpid())
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", config.port), mux))
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", config.port), lockRequests(habitsLock, mux, "/my/live")))
 }
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	friends "github.com/Joshua-Hwang/habits2share/pkg/friends"
 	habit_share "github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 	todo "github.com/Joshua-Hwang/habits2share/pkg/todo"
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +37,20 @@ func (m *MockHabitAppInterface) EXPECT() *MockHabitAppInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockHabitAppInterface) AcceptInvitation(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockHabitAppInterfaceMockRecorder) AcceptInvitation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockHabitAppInterface)(nil).AcceptInvitation), id)
+}
+
 // ArchiveHabit mocks base method.
 func (m *MockHabitAppInterface) ArchiveHabit(id string) error {
 	m.ctrl.T.Helper()
@@ -50,6 +65,20 @@ func (mr *MockHabitAppInterfaceMockRecorder) ArchiveHabit(id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).ArchiveHabit), id)
 }
 
+// CancelInvitation mocks base method.
+func (m *MockHabitAppInterface) CancelInvitation(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelInvitation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelInvitation indicates an expected call of CancelInvitation.
+func (mr *MockHabitAppInterfaceMockRecorder) CancelInvitation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInvitation", reflect.TypeOf((*MockHabitAppInterface)(nil).CancelInvitation), id)
+}
+
 // ChangeDescription mocks base method.
 func (m *MockHabitAppInterface) ChangeDescription(id, newDescription string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +93,20 @@ func (mr *MockHabitAppInterfaceMockRecorder) ChangeDescription(id, newDescriptio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeDescription", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeDescription), id, newDescription)
 }
 
+// ChangeExcuseLimit mocks base method.
+func (m *MockHabitAppInterface) ChangeExcuseLimit(id string, excusesPerMonth int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeExcuseLimit", id, excusesPerMonth)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeExcuseLimit indicates an expected call of ChangeExcuseLimit.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangeExcuseLimit(id, excusesPerMonth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeExcuseLimit", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeExcuseLimit), id, excusesPerMonth)
+}
+
 // ChangeFrequency mocks base method.
 func (m *MockHabitAppInterface) ChangeFrequency(id string, newFrequency int) error {
 	m.ctrl.T.Helper()
@@ -78,6 +121,20 @@ func (mr *MockHabitAppInterfaceMockRecorder) ChangeFrequency(id, newFrequency in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeFrequency", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeFrequency), id, newFrequency)
 }
 
+// ChangeGroupMode mocks base method.
+func (m *MockHabitAppInterface) ChangeGroupMode(id, mode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeGroupMode", id, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeGroupMode indicates an expected call of ChangeGroupMode.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangeGroupMode(id, mode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGroupMode", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeGroupMode), id, mode)
+}
+
 // ChangeName mocks base method.
 func (m *MockHabitAppInterface) ChangeName(id, newName string) error {
 	m.ctrl.T.Helper()
@@ -92,34 +149,178 @@ func (mr *MockHabitAppInterfaceMockRecorder) ChangeName(id, newName interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeName", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeName), id, newName)
 }
 
+// ChangePeriod mocks base method.
+func (m *MockHabitAppInterface) ChangePeriod(id string, newPeriod habit_share.Period, newFrequency int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePeriod", id, newPeriod, newFrequency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePeriod indicates an expected call of ChangePeriod.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangePeriod(id, newPeriod, newFrequency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePeriod", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangePeriod), id, newPeriod, newFrequency)
+}
+
+// ChangeSchedule mocks base method.
+func (m *MockHabitAppInterface) ChangeSchedule(id string, weekdays []time.Weekday) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeSchedule", id, weekdays)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeSchedule indicates an expected call of ChangeSchedule.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangeSchedule(id, weekdays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSchedule", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeSchedule), id, weekdays)
+}
+
+// ChangeScoring mocks base method.
+func (m *MockHabitAppInterface) ChangeScoring(id, scoring string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeScoring", id, scoring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeScoring indicates an expected call of ChangeScoring.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangeScoring(id, scoring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeScoring", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeScoring), id, scoring)
+}
+
+// ChangeSettings mocks base method.
+func (m *MockHabitAppInterface) ChangeSettings(timezone string, dayStartHour int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeSettings", timezone, dayStartHour)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeSettings indicates an expected call of ChangeSettings.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangeSettings(timezone, dayStartHour interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSettings", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeSettings), timezone, dayStartHour)
+}
+
+// ChangeTarget mocks base method.
+func (m *MockHabitAppInterface) ChangeTarget(id, unit string, target, minimum float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTarget", id, unit, target, minimum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeTarget indicates an expected call of ChangeTarget.
+func (mr *MockHabitAppInterfaceMockRecorder) ChangeTarget(id, unit, target, minimum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTarget", reflect.TypeOf((*MockHabitAppInterface)(nil).ChangeTarget), id, unit, target, minimum)
+}
+
 // CreateActivity mocks base method.
-func (m *MockHabitAppInterface) CreateActivity(habitId string, logged habit_share.Time, status string) (string, error) {
+func (m *MockHabitAppInterface) CreateActivity(habitId string, logged habit_share.Time, status string, amount float64) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateActivity", habitId, logged, status)
+	ret := m.ctrl.Call(m, "CreateActivity", habitId, logged, status, amount)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateActivity indicates an expected call of CreateActivity.
-func (mr *MockHabitAppInterfaceMockRecorder) CreateActivity(habitId, logged, status interface{}) *gomock.Call {
+func (mr *MockHabitAppInterfaceMockRecorder) CreateActivity(habitId, logged, status, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateActivity), habitId, logged, status, amount)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockHabitAppInterface) CreateChallenge(name, description string, start, end habit_share.Time, frequency int, period habit_share.Period, participants []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", name, description, start, end, frequency, period, participants)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockHabitAppInterfaceMockRecorder) CreateChallenge(name, description, start, end, frequency, period, participants interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateActivity), habitId, logged, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateChallenge), name, description, start, end, frequency, period, participants)
+}
+
+// CreateGroupHabit mocks base method.
+func (m *MockHabitAppInterface) CreateGroupHabit(name string, frequency int, period habit_share.Period, members []string, mode string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupHabit", name, frequency, period, members, mode)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupHabit indicates an expected call of CreateGroupHabit.
+func (mr *MockHabitAppInterfaceMockRecorder) CreateGroupHabit(name, frequency, period, members, mode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateGroupHabit), name, frequency, period, members, mode)
 }
 
 // CreateHabit mocks base method.
-func (m *MockHabitAppInterface) CreateHabit(name string, frequency int) (string, error) {
+func (m *MockHabitAppInterface) CreateHabit(name string, frequency int, period habit_share.Period) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateHabit", name, frequency)
+	ret := m.ctrl.Call(m, "CreateHabit", name, frequency, period)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateHabit indicates an expected call of CreateHabit.
-func (mr *MockHabitAppInterfaceMockRecorder) CreateHabit(name, frequency interface{}) *gomock.Call {
+func (mr *MockHabitAppInterfaceMockRecorder) CreateHabit(name, frequency, period interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateHabit), name, frequency)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateHabit), name, frequency, period)
+}
+
+// CreatePublicLink mocks base method.
+func (m *MockHabitAppInterface) CreatePublicLink(habitId string) (habit_share.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePublicLink", habitId)
+	ret0, _ := ret[0].(habit_share.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePublicLink indicates an expected call of CreatePublicLink.
+func (mr *MockHabitAppInterfaceMockRecorder) CreatePublicLink(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePublicLink", reflect.TypeOf((*MockHabitAppInterface)(nil).CreatePublicLink), habitId)
+}
+
+// CreateVacation mocks base method.
+func (m *MockHabitAppInterface) CreateVacation(start, end habit_share.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVacation", start, end)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVacation indicates an expected call of CreateVacation.
+func (mr *MockHabitAppInterfaceMockRecorder) CreateVacation(start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVacation", reflect.TypeOf((*MockHabitAppInterface)(nil).CreateVacation), start, end)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockHabitAppInterface) DeclineInvitation(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockHabitAppInterfaceMockRecorder) DeclineInvitation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockHabitAppInterface)(nil).DeclineInvitation), id)
 }
 
 // DeleteActivity mocks base method.
@@ -136,6 +337,20 @@ func (mr *MockHabitAppInterfaceMockRecorder) DeleteActivity(habitId, id interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActivity", reflect.TypeOf((*MockHabitAppInterface)(nil).DeleteActivity), habitId, id)
 }
 
+// DeleteComment mocks base method.
+func (m *MockHabitAppInterface) DeleteComment(habitId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", habitId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockHabitAppInterfaceMockRecorder) DeleteComment(habitId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockHabitAppInterface)(nil).DeleteComment), habitId, id)
+}
+
 // DeleteHabit mocks base method.
 func (m *MockHabitAppInterface) DeleteHabit(id string) error {
 	m.ctrl.T.Helper()
@@ -150,6 +365,48 @@ func (mr *MockHabitAppInterfaceMockRecorder) DeleteHabit(id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).DeleteHabit), id)
 }
 
+// DeleteNotification mocks base method.
+func (m *MockHabitAppInterface) DeleteNotification(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotification", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotification indicates an expected call of DeleteNotification.
+func (mr *MockHabitAppInterfaceMockRecorder) DeleteNotification(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockHabitAppInterface)(nil).DeleteNotification), id)
+}
+
+// DeleteVacation mocks base method.
+func (m *MockHabitAppInterface) DeleteVacation(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVacation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVacation indicates an expected call of DeleteVacation.
+func (mr *MockHabitAppInterfaceMockRecorder) DeleteVacation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVacation", reflect.TypeOf((*MockHabitAppInterface)(nil).DeleteVacation), id)
+}
+
+// EditComment mocks base method.
+func (m *MockHabitAppInterface) EditComment(habitId, id, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditComment", habitId, id, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditComment indicates an expected call of EditComment.
+func (mr *MockHabitAppInterfaceMockRecorder) EditComment(habitId, id, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditComment", reflect.TypeOf((*MockHabitAppInterface)(nil).EditComment), habitId, id, body)
+}
+
 // GetActivities mocks base method.
 func (m *MockHabitAppInterface) GetActivities(habitId string, after, before habit_share.Time, limit int) ([]habit_share.Activity, bool, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +423,53 @@ func (mr *MockHabitAppInterfaceMockRecorder) GetActivities(habitId, after, befor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivities", reflect.TypeOf((*MockHabitAppInterface)(nil).GetActivities), habitId, after, before, limit)
 }
 
+// GetChallenge mocks base method.
+func (m *MockHabitAppInterface) GetChallenge(id string) (habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", id)
+	ret0, _ := ret[0].(habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockHabitAppInterfaceMockRecorder) GetChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockHabitAppInterface)(nil).GetChallenge), id)
+}
+
+// GetComments mocks base method.
+func (m *MockHabitAppInterface) GetComments(habitId string, before time.Time, limit int) ([]habit_share.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", habitId, before, limit)
+	ret0, _ := ret[0].([]habit_share.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockHabitAppInterfaceMockRecorder) GetComments(habitId, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockHabitAppInterface)(nil).GetComments), habitId, before, limit)
+}
+
+// GetFeed mocks base method.
+func (m *MockHabitAppInterface) GetFeed(cursor int64, limit int) ([]habit_share.Event, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", cursor, limit)
+	ret0, _ := ret[0].([]habit_share.Event)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockHabitAppInterfaceMockRecorder) GetFeed(cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockHabitAppInterface)(nil).GetFeed), cursor, limit)
+}
+
 // GetHabit mocks base method.
 func (m *MockHabitAppInterface) GetHabit(id string) (habit_share.Habit, error) {
 	m.ctrl.T.Helper()
@@ -181,6 +485,66 @@ func (mr *MockHabitAppInterfaceMockRecorder) GetHabit(id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).GetHabit), id)
 }
 
+// GetLeaderboard mocks base method.
+func (m *MockHabitAppInterface) GetLeaderboard(metric string, days int) ([]habit_share.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", metric, days)
+	ret0, _ := ret[0].([]habit_share.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockHabitAppInterfaceMockRecorder) GetLeaderboard(metric, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockHabitAppInterface)(nil).GetLeaderboard), metric, days)
+}
+
+// GetMemberStatuses mocks base method.
+func (m *MockHabitAppInterface) GetMemberStatuses(habitId string) ([]habit_share.MemberStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberStatuses", habitId)
+	ret0, _ := ret[0].([]habit_share.MemberStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberStatuses indicates an expected call of GetMemberStatuses.
+func (mr *MockHabitAppInterfaceMockRecorder) GetMemberStatuses(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberStatuses", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMemberStatuses), habitId)
+}
+
+// GetMutedHabits mocks base method.
+func (m *MockHabitAppInterface) GetMutedHabits(limit int) ([]habit_share.Habit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutedHabits", limit)
+	ret0, _ := ret[0].([]habit_share.Habit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutedHabits indicates an expected call of GetMutedHabits.
+func (mr *MockHabitAppInterfaceMockRecorder) GetMutedHabits(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutedHabits", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMutedHabits), limit)
+}
+
+// GetMyChallenges mocks base method.
+func (m *MockHabitAppInterface) GetMyChallenges() ([]habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyChallenges")
+	ret0, _ := ret[0].([]habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyChallenges indicates an expected call of GetMyChallenges.
+func (mr *MockHabitAppInterfaceMockRecorder) GetMyChallenges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyChallenges", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMyChallenges))
+}
+
 // GetMyHabits mocks base method.
 func (m *MockHabitAppInterface) GetMyHabits(limit int, archived bool) ([]habit_share.Habit, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +560,112 @@ func (mr *MockHabitAppInterfaceMockRecorder) GetMyHabits(limit, archived interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyHabits", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMyHabits), limit, archived)
 }
 
+// GetMyInvitations mocks base method.
+func (m *MockHabitAppInterface) GetMyInvitations() ([]habit_share.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyInvitations")
+	ret0, _ := ret[0].([]habit_share.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyInvitations indicates an expected call of GetMyInvitations.
+func (mr *MockHabitAppInterfaceMockRecorder) GetMyInvitations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyInvitations", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMyInvitations))
+}
+
+// GetMySettings mocks base method.
+func (m *MockHabitAppInterface) GetMySettings() (habit_share.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMySettings")
+	ret0, _ := ret[0].(habit_share.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMySettings indicates an expected call of GetMySettings.
+func (mr *MockHabitAppInterfaceMockRecorder) GetMySettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMySettings", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMySettings))
+}
+
+// GetMyVacations mocks base method.
+func (m *MockHabitAppInterface) GetMyVacations() ([]habit_share.Vacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyVacations")
+	ret0, _ := ret[0].([]habit_share.Vacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyVacations indicates an expected call of GetMyVacations.
+func (mr *MockHabitAppInterfaceMockRecorder) GetMyVacations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyVacations", reflect.TypeOf((*MockHabitAppInterface)(nil).GetMyVacations))
+}
+
+// GetNotifications mocks base method.
+func (m *MockHabitAppInterface) GetNotifications(limit int, unread bool) ([]habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", limit, unread)
+	ret0, _ := ret[0].([]habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockHabitAppInterfaceMockRecorder) GetNotifications(limit, unread interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockHabitAppInterface)(nil).GetNotifications), limit, unread)
+}
+
+// GetOwnerVacation mocks base method.
+func (m *MockHabitAppInterface) GetOwnerVacation(habitId string) (habit_share.Vacation, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerVacation", habitId)
+	ret0, _ := ret[0].(habit_share.Vacation)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOwnerVacation indicates an expected call of GetOwnerVacation.
+func (mr *MockHabitAppInterfaceMockRecorder) GetOwnerVacation(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerVacation", reflect.TypeOf((*MockHabitAppInterface)(nil).GetOwnerVacation), habitId)
+}
+
+// GetPublicLinks mocks base method.
+func (m *MockHabitAppInterface) GetPublicLinks(habitId string) ([]habit_share.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicLinks", habitId)
+	ret0, _ := ret[0].([]habit_share.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicLinks indicates an expected call of GetPublicLinks.
+func (mr *MockHabitAppInterfaceMockRecorder) GetPublicLinks(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicLinks", reflect.TypeOf((*MockHabitAppInterface)(nil).GetPublicLinks), habitId)
+}
+
+// GetReactions mocks base method.
+func (m *MockHabitAppInterface) GetReactions(habitId string) ([]habit_share.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactions", habitId)
+	ret0, _ := ret[0].([]habit_share.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactions indicates an expected call of GetReactions.
+func (mr *MockHabitAppInterfaceMockRecorder) GetReactions(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockHabitAppInterface)(nil).GetReactions), habitId)
+}
+
 // GetScore mocks base method.
 func (m *MockHabitAppInterface) GetScore(habitId string) (int, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +681,21 @@ func (mr *MockHabitAppInterfaceMockRecorder) GetScore(habitId interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScore", reflect.TypeOf((*MockHabitAppInterface)(nil).GetScore), habitId)
 }
 
+// GetSentInvitations mocks base method.
+func (m *MockHabitAppInterface) GetSentInvitations() ([]habit_share.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentInvitations")
+	ret0, _ := ret[0].([]habit_share.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentInvitations indicates an expected call of GetSentInvitations.
+func (mr *MockHabitAppInterfaceMockRecorder) GetSentInvitations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentInvitations", reflect.TypeOf((*MockHabitAppInterface)(nil).GetSentInvitations))
+}
+
 // GetSharedHabits mocks base method.
 func (m *MockHabitAppInterface) GetSharedHabits(limit int) ([]habit_share.Habit, error) {
 	m.ctrl.T.Helper()
@@ -226,18 +711,163 @@ func (mr *MockHabitAppInterfaceMockRecorder) GetSharedHabits(limit interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedHabits", reflect.TypeOf((*MockHabitAppInterface)(nil).GetSharedHabits), limit)
 }
 
+// GetStats mocks base method.
+func (m *MockHabitAppInterface) GetStats(habitId string) (habit_share.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", habitId)
+	ret0, _ := ret[0].(habit_share.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockHabitAppInterfaceMockRecorder) GetStats(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockHabitAppInterface)(nil).GetStats), habitId)
+}
+
+// HabitToday mocks base method.
+func (m *MockHabitAppInterface) HabitToday(habitId string) (habit_share.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HabitToday", habitId)
+	ret0, _ := ret[0].(habit_share.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HabitToday indicates an expected call of HabitToday.
+func (mr *MockHabitAppInterfaceMockRecorder) HabitToday(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HabitToday", reflect.TypeOf((*MockHabitAppInterface)(nil).HabitToday), habitId)
+}
+
+// InviteToHabit mocks base method.
+func (m *MockHabitAppInterface) InviteToHabit(habitId, to string, permission habit_share.Permission) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteToHabit", habitId, to, permission)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteToHabit indicates an expected call of InviteToHabit.
+func (mr *MockHabitAppInterfaceMockRecorder) InviteToHabit(habitId, to, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteToHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).InviteToHabit), habitId, to, permission)
+}
+
+// JoinChallenge mocks base method.
+func (m *MockHabitAppInterface) JoinChallenge(id string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinChallenge", id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinChallenge indicates an expected call of JoinChallenge.
+func (mr *MockHabitAppInterfaceMockRecorder) JoinChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinChallenge", reflect.TypeOf((*MockHabitAppInterface)(nil).JoinChallenge), id)
+}
+
+// LeaveHabit mocks base method.
+func (m *MockHabitAppInterface) LeaveHabit(habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveHabit", habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveHabit indicates an expected call of LeaveHabit.
+func (mr *MockHabitAppInterfaceMockRecorder) LeaveHabit(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).LeaveHabit), habitId)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockHabitAppInterface) MarkNotificationRead(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockHabitAppInterfaceMockRecorder) MarkNotificationRead(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockHabitAppInterface)(nil).MarkNotificationRead), id)
+}
+
+// MuteHabit mocks base method.
+func (m *MockHabitAppInterface) MuteHabit(habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteHabit", habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteHabit indicates an expected call of MuteHabit.
+func (mr *MockHabitAppInterfaceMockRecorder) MuteHabit(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).MuteHabit), habitId)
+}
+
+// PostComment mocks base method.
+func (m *MockHabitAppInterface) PostComment(habitId, body string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostComment", habitId, body)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostComment indicates an expected call of PostComment.
+func (mr *MockHabitAppInterfaceMockRecorder) PostComment(habitId, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostComment", reflect.TypeOf((*MockHabitAppInterface)(nil).PostComment), habitId, body)
+}
+
+// React mocks base method.
+func (m *MockHabitAppInterface) React(habitId, activityId, emoji string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", habitId, activityId, emoji)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockHabitAppInterfaceMockRecorder) React(habitId, activityId, emoji interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockHabitAppInterface)(nil).React), habitId, activityId, emoji)
+}
+
+// RevokePublicLink mocks base method.
+func (m *MockHabitAppInterface) RevokePublicLink(habitId, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePublicLink", habitId, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePublicLink indicates an expected call of RevokePublicLink.
+func (mr *MockHabitAppInterfaceMockRecorder) RevokePublicLink(habitId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePublicLink", reflect.TypeOf((*MockHabitAppInterface)(nil).RevokePublicLink), habitId, token)
+}
+
 // ShareHabit mocks base method.
-func (m *MockHabitAppInterface) ShareHabit(habitId, friend string) error {
+func (m *MockHabitAppInterface) ShareHabit(habitId, friend string, permission habit_share.Permission, expires *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShareHabit", habitId, friend)
+	ret := m.ctrl.Call(m, "ShareHabit", habitId, friend, permission, expires)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ShareHabit indicates an expected call of ShareHabit.
-func (mr *MockHabitAppInterfaceMockRecorder) ShareHabit(habitId, friend interface{}) *gomock.Call {
+func (mr *MockHabitAppInterfaceMockRecorder) ShareHabit(habitId, friend, permission, expires interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).ShareHabit), habitId, friend)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).ShareHabit), habitId, friend, permission, expires)
 }
 
 // UnShareHabit mocks base method.
@@ -254,6 +884,34 @@ func (mr *MockHabitAppInterfaceMockRecorder) UnShareHabit(habitId, friend interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnShareHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).UnShareHabit), habitId, friend)
 }
 
+// UnmuteHabit mocks base method.
+func (m *MockHabitAppInterface) UnmuteHabit(habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteHabit", habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteHabit indicates an expected call of UnmuteHabit.
+func (mr *MockHabitAppInterfaceMockRecorder) UnmuteHabit(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteHabit", reflect.TypeOf((*MockHabitAppInterface)(nil).UnmuteHabit), habitId)
+}
+
+// Unreact mocks base method.
+func (m *MockHabitAppInterface) Unreact(habitId, activityId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unreact", habitId, activityId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unreact indicates an expected call of Unreact.
+func (mr *MockHabitAppInterfaceMockRecorder) Unreact(habitId, activityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unreact", reflect.TypeOf((*MockHabitAppInterface)(nil).Unreact), habitId, activityId)
+}
+
 // MockTodoAppInterface is a mock of TodoAppInterface interface.
 type MockTodoAppInterface struct {
 	ctrl     *gomock.Controller
@@ -377,3 +1035,184 @@ func (mr *MockTodoAppInterfaceMockRecorder) GetTodo(todoId interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodo", reflect.TypeOf((*MockTodoAppInterface)(nil).GetTodo), todoId)
 }
+
+// MockFriendsAppInterface is a mock of FriendsAppInterface interface.
+type MockFriendsAppInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFriendsAppInterfaceMockRecorder
+}
+
+// MockFriendsAppInterfaceMockRecorder is the mock recorder for MockFriendsAppInterface.
+type MockFriendsAppInterfaceMockRecorder struct {
+	mock *MockFriendsAppInterface
+}
+
+// NewMockFriendsAppInterface creates a new mock instance.
+func NewMockFriendsAppInterface(ctrl *gomock.Controller) *MockFriendsAppInterface {
+	mock := &MockFriendsAppInterface{ctrl: ctrl}
+	mock.recorder = &MockFriendsAppInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFriendsAppInterface) EXPECT() *MockFriendsAppInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptRequest mocks base method.
+func (m *MockFriendsAppInterface) AcceptRequest(from string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptRequest", from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptRequest indicates an expected call of AcceptRequest.
+func (mr *MockFriendsAppInterfaceMockRecorder) AcceptRequest(from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptRequest", reflect.TypeOf((*MockFriendsAppInterface)(nil).AcceptRequest), from)
+}
+
+// Block mocks base method.
+func (m *MockFriendsAppInterface) Block(blocked string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", blocked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockFriendsAppInterfaceMockRecorder) Block(blocked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockFriendsAppInterface)(nil).Block), blocked)
+}
+
+// CancelRequest mocks base method.
+func (m *MockFriendsAppInterface) CancelRequest(to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRequest", to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelRequest indicates an expected call of CancelRequest.
+func (mr *MockFriendsAppInterfaceMockRecorder) CancelRequest(to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRequest", reflect.TypeOf((*MockFriendsAppInterface)(nil).CancelRequest), to)
+}
+
+// DeclineRequest mocks base method.
+func (m *MockFriendsAppInterface) DeclineRequest(from string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineRequest", from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineRequest indicates an expected call of DeclineRequest.
+func (mr *MockFriendsAppInterfaceMockRecorder) DeclineRequest(from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineRequest", reflect.TypeOf((*MockFriendsAppInterface)(nil).DeclineRequest), from)
+}
+
+// GetBlocked mocks base method.
+func (m *MockFriendsAppInterface) GetBlocked() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocked")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocked indicates an expected call of GetBlocked.
+func (mr *MockFriendsAppInterfaceMockRecorder) GetBlocked() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocked", reflect.TypeOf((*MockFriendsAppInterface)(nil).GetBlocked))
+}
+
+// GetFriends mocks base method.
+func (m *MockFriendsAppInterface) GetFriends() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFriends")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFriends indicates an expected call of GetFriends.
+func (mr *MockFriendsAppInterfaceMockRecorder) GetFriends() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriends", reflect.TypeOf((*MockFriendsAppInterface)(nil).GetFriends))
+}
+
+// GetIncomingRequests mocks base method.
+func (m *MockFriendsAppInterface) GetIncomingRequests() ([]friends.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingRequests")
+	ret0, _ := ret[0].([]friends.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingRequests indicates an expected call of GetIncomingRequests.
+func (mr *MockFriendsAppInterfaceMockRecorder) GetIncomingRequests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingRequests", reflect.TypeOf((*MockFriendsAppInterface)(nil).GetIncomingRequests))
+}
+
+// GetOutgoingRequests mocks base method.
+func (m *MockFriendsAppInterface) GetOutgoingRequests() ([]friends.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingRequests")
+	ret0, _ := ret[0].([]friends.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingRequests indicates an expected call of GetOutgoingRequests.
+func (mr *MockFriendsAppInterfaceMockRecorder) GetOutgoingRequests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingRequests", reflect.TypeOf((*MockFriendsAppInterface)(nil).GetOutgoingRequests))
+}
+
+// RemoveFriend mocks base method.
+func (m *MockFriendsAppInterface) RemoveFriend(friend string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFriend", friend)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFriend indicates an expected call of RemoveFriend.
+func (mr *MockFriendsAppInterfaceMockRecorder) RemoveFriend(friend interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFriend", reflect.TypeOf((*MockFriendsAppInterface)(nil).RemoveFriend), friend)
+}
+
+// SendRequest mocks base method.
+func (m *MockFriendsAppInterface) SendRequest(to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRequest", to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRequest indicates an expected call of SendRequest.
+func (mr *MockFriendsAppInterfaceMockRecorder) SendRequest(to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRequest", reflect.TypeOf((*MockFriendsAppInterface)(nil).SendRequest), to)
+}
+
+// Unblock mocks base method.
+func (m *MockFriendsAppInterface) Unblock(blocked string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", blocked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockFriendsAppInterfaceMockRecorder) Unblock(blocked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockFriendsAppInterface)(nil).Unblock), blocked)
+}
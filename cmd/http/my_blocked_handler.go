@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
+)
+
+func (s Server) GetMyBlocked(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	blocked, err := app.GetBlocked()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetBlocked failed")
+		log.Printf("GetBlocked failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(blocked)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+func (s Server) PostMyBlocked(w http.ResponseWriter, r *http.Request) {
+	var err error
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "Content Type is not application/json")
+		return
+	}
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	newBlock := struct {
+		User string
+	}{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&newBlock)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		var unmarshalErr *json.UnmarshalTypeError
+		if errors.As(err, &unmarshalErr) {
+			fmt.Fprintf(w, "Bad Request. Wrong Type provided for field: %s", unmarshalErr.Field)
+		} else {
+			fmt.Fprintf(w, "Bad Request: %s", err)
+		}
+		return
+	}
+
+	if found, err := s.AuthDatabase.UserExists(r.Context(), newBlock.User); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Unable to block user")
+		log.Printf("Unable to check user exists: %v", err)
+		return
+	} else if !found {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "User doesn't exist")
+		return
+	}
+
+	err = app.Block(newBlock.User)
+	if err != nil {
+		if inputError := (*friends.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Input was not valid: %s", inputError.Message)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong blocking user")
+		log.Printf("Something has gone wrong blocking user: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s Server) DeleteMyBlocked(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "my" || splits[2] != "blocked" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "User id invalid")
+		return
+	}
+	blocked := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	err = app.Unblock(blocked)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong unblocking user")
+		log.Printf("Something has gone wrong unblocking user: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyBlocked(t *testing.T) {
+	t.Run("GET returns who the user blocked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().GetBlocked().Return([]string{"friend"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/blocked", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyBlocked(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		blocked := []string{}
+		if err := json.NewDecoder(res.Body).Decode(&blocked); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if len(blocked) != 1 || blocked[0] != "friend" {
+			t.Error("expected friend got", blocked)
+		}
+	})
+
+	t.Run("POST blocks the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().Block("friend")
+
+		req := httptest.NewRequest(http.MethodPost, "/my/blocked", strings.NewReader("{\"User\": \"friend\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyBlocked(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST an unknown user is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		req := httptest.NewRequest(http.MethodPost, "/my/blocked", strings.NewReader("{\"User\": \"stranger\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyBlocked(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("DELETE /my/blocked/:user unblocks them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().Unblock("friend")
+
+		req := httptest.NewRequest(http.MethodDelete, "/my/blocked/friend", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.DeleteMyBlocked(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNoContent {
+			t.Error("expected status code to be", http.StatusNoContent, "got", res.StatusCode)
+		}
+	})
+}
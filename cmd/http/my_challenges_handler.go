@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func writeChallenges(w http.ResponseWriter, challenges []habit_share.Challenge) {
+	res, err := json.Marshal(challenges)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+// GetMyChallenges lists the challenges the current user can take part in
+func (s Server) GetMyChallenges(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	challenges, err := app.GetMyChallenges()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetMyChallenges failed")
+		log.Printf("GetMyChallenges failed with %v", err)
+		return
+	}
+
+	writeChallenges(w, challenges)
+}
+
+// PostMyChallenges creates a challenge the current user organizes and joins
+func (s Server) PostMyChallenges(w http.ResponseWriter, r *http.Request) {
+	var err error
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "Content Type is not application/json")
+		return
+	}
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	newChallenge := struct {
+		Name        string
+		Description string
+		// both inclusive
+		Start string
+		End   string
+		// of the habit everyone gets, the Period defaults to a week
+		Frequency int
+		Period    habit_share.Period
+		// friends who may join
+		Participants []string
+	}{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&newChallenge)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		var unmarshalErr *json.UnmarshalTypeError
+		if errors.As(err, &unmarshalErr) {
+			fmt.Fprintf(w, "Bad Request. Wrong Type provided for field: %s", unmarshalErr.Field)
+		} else {
+			fmt.Fprintf(w, "Bad Request: %s", err)
+		}
+		return
+	}
+
+	start, err := time.Parse(habit_share.DateFormat, newChallenge.Start)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Bad Request, Start must be in YYYY-mm-dd format")
+		return
+	}
+	end, err := time.Parse(habit_share.DateFormat, newChallenge.End)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Bad Request, End must be in YYYY-mm-dd format")
+		return
+	}
+
+	challengeId, err := app.CreateChallenge(
+		newChallenge.Name,
+		newChallenge.Description,
+		habit_share.Time{Time: start},
+		habit_share.Time{Time: end},
+		newChallenge.Frequency,
+		newChallenge.Period,
+		newChallenge.Participants,
+	)
+	if err != nil {
+		if err == habit_share.NotFriendsError {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "Participants must all be friends")
+			return
+		}
+		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Input was not valid, End must not be before Start or today. %s", inputError)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong creating challenge")
+		log.Printf("Something has gone wrong creating challenge: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, challengeId)
+}
+
+// GetMyChallenge returns the challenge with its standings
+func (s Server) GetMyChallenge(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "my" || splits[2] != "challenges" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Challenge id invalid")
+		return
+	}
+	challengeId := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	challenge, err := app.GetChallenge(challengeId)
+	if err == habit_share.ChallengeNotFoundError {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetChallenge failed")
+		log.Printf("GetChallenge failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(challenge)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+// PostMyChallenge joins the challenge, responding with the id of the habit
+// made for it
+func (s Server) PostMyChallenge(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 5)
+	if len(splits) != 5 || splits[1] != "my" || splits[2] != "challenges" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Challenge id invalid")
+		return
+	}
+	challengeId := splits[3]
+	if splits[4] != "join" {
+		http.NotFound(w, r)
+		return
+	}
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	habitId, err := app.JoinChallenge(challengeId)
+	if err != nil {
+		if err == habit_share.ChallengeNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		if err == habit_share.ChallengeOverError {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "Challenge is over")
+			return
+		}
+		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Challenge already joined")
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong joining challenge")
+		log.Printf("Something has gone wrong joining challenge: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, habitId)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyChallenges(t *testing.T) {
+	t.Run("GET /my/challenges/:id returns the challenge", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetChallenge("mock-id").
+			Return(habit_share.Challenge{Id: "mock-id", Name: "mock name", Organizer: "friend"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/challenges/mock-id", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyChallenge(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		challenge := struct {
+			Id        string
+			Organizer string
+		}{}
+		if err := json.NewDecoder(res.Body).Decode(&challenge); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if challenge.Id != "mock-id" || challenge.Organizer != "friend" {
+			t.Error("expected the challenge got", challenge)
+		}
+	})
+
+	t.Run("GET /my/challenges/:id of an unknown challenge is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetChallenge("mock-id").Return(habit_share.Challenge{}, habit_share.ChallengeNotFoundError)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/challenges/mock-id", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyChallenge(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Error("expected status code to be", http.StatusNotFound, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST /my/challenges/:id/join returns the new habit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().JoinChallenge("mock-id").Return("habit id", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/my/challenges/mock-id/join", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyChallenge(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+		if body, _ := io.ReadAll(res.Body); string(body) != "habit id" {
+			t.Error("expected the habit id got", string(body))
+		}
+	})
+
+	t.Run("POST /my/challenges/:id/join after it ends conflicts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().JoinChallenge("mock-id").Return("", habit_share.ChallengeOverError)
+
+		req := httptest.NewRequest(http.MethodPost, "/my/challenges/mock-id/join", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyChallenge(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusConflict {
+			t.Error("expected status code to be", http.StatusConflict, "got", res.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// GetMyFeed lists what happened on habits shared with the current user, newest
+// first. Pass the NextCursor of a response as ?cursor= to get older events.
+func (s Server) GetMyFeed(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	cursorString := r.URL.Query().Get("cursor")
+	if cursorString == "" {
+		cursorString = "0"
+	}
+	cursor, err := strconv.ParseInt(cursorString, 10, 64)
+	if err != nil || cursor < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Cursor query is in incorrect, must be a cursor from a previous response")
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		limitString = "20"
+	}
+	limit, err := strconv.Atoi(limitString)
+	if err != nil || limit < 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Limit query is in incorrect, must be a positive integer")
+		return
+	}
+
+	events, nextCursor, err := app.GetFeed(cursor, limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetFeed failed")
+		log.Printf("GetFeed failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(struct {
+		Events []habit_share.Event
+		// 0 when there are no older events
+		NextCursor int64
+	}{Events: events, NextCursor: nextCursor})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
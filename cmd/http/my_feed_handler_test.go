@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyFeed(t *testing.T) {
+	t.Run("GET returns the page and next cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetFeed(int64(10), 2).
+			Return([]habit_share.Event{{Id: 9}, {Id: 8}}, int64(8), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/feed?cursor=10&limit=2", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyFeed(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		resPayload := struct {
+			Events     []habit_share.Event
+			NextCursor int64
+		}{}
+		if err := json.NewDecoder(res.Body).Decode(&resPayload); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if len(resPayload.Events) != 2 || resPayload.NextCursor != 8 {
+			t.Error("expected 2 events and cursor 8 got", resPayload)
+		}
+	})
+
+	t.Run("GET rejects a bad cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/feed?cursor=-1", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyFeed(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("GET without a session is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, _ := newTestServer(t, habitApp, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/feed", nil)
+		w := httptest.NewRecorder()
+		server.GetMyFeed(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusForbidden {
+			t.Error("expected status code to be", http.StatusForbidden, "got", res.StatusCode)
+		}
+	})
+}
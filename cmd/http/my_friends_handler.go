@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
+)
+
+func (s Server) GetMyFriends(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	myFriends, err := app.GetFriends()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetFriends failed")
+		log.Printf("GetFriends failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(myFriends)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+func (s Server) DeleteMyFriend(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "my" || splits[2] != "friends" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Friend id invalid")
+		return
+	}
+	friend := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	err = app.RemoveFriend(friend)
+	if err != nil {
+		if err == friends.NotFriendsError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong removing friend")
+		log.Printf("Something has gone wrong removing friend: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s Server) GetMyFriendRequests(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	incoming, err := app.GetIncomingRequests()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetIncomingRequests failed")
+		log.Printf("GetIncomingRequests failed with %v", err)
+		return
+	}
+	outgoing, err := app.GetOutgoingRequests()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetOutgoingRequests failed")
+		log.Printf("GetOutgoingRequests failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(struct {
+		Incoming []friends.FriendRequest
+		Outgoing []friends.FriendRequest
+	}{Incoming: incoming, Outgoing: outgoing})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+func (s Server) PostMyFriendRequests(w http.ResponseWriter, r *http.Request) {
+	var err error
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "Content Type is not application/json")
+		return
+	}
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	newRequest := struct {
+		To string
+	}{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&newRequest)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		var unmarshalErr *json.UnmarshalTypeError
+		if errors.As(err, &unmarshalErr) {
+			fmt.Fprintf(w, "Bad Request. Wrong Type provided for field: %s", unmarshalErr.Field)
+		} else {
+			fmt.Fprintf(w, "Bad Request: %s", err)
+		}
+		return
+	}
+
+	if found, err := s.AuthDatabase.UserExists(r.Context(), newRequest.To); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Unable to send friend request")
+		log.Printf("Unable to check user exists: %v", err)
+		return
+	} else if !found {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "User doesn't exist")
+		return
+	}
+
+	err = app.SendRequest(newRequest.To)
+	if err != nil {
+		if inputError := (*friends.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Input was not valid: %s", inputError.Message)
+			return
+		}
+		if err == friends.AlreadyFriendsError {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "Already friends")
+			return
+		}
+		if err == friends.BlockedError {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "Unblock that user before befriending them")
+			return
+		}
+		if err == friends.BlockedByError {
+			// answer like a pending request so the block isn't revealed
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong sending friend request")
+		log.Printf("Something has gone wrong sending friend request: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// PostMyFriendRequest accepts or declines the request from a user
+func (s Server) PostMyFriendRequest(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 5)
+	if len(splits) != 5 || splits[1] != "my" || splits[2] != "friend-requests" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "User id invalid")
+		return
+	}
+	from := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	switch splits[4] {
+	case "accept":
+		err = app.AcceptRequest(from)
+	case "decline":
+		err = app.DeclineRequest(from)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		if err == friends.FriendRequestNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong answering friend request")
+		log.Printf("Something has gone wrong answering friend request: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteMyFriendRequest cancels the request sent to a user
+func (s Server) DeleteMyFriendRequest(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "my" || splits[2] != "friend-requests" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "User id invalid")
+		return
+	}
+	to := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.FriendsApp
+
+	err = app.CancelRequest(to)
+	if err != nil {
+		if err == friends.FriendRequestNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong cancelling friend request")
+		log.Printf("Something has gone wrong cancelling friend request: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
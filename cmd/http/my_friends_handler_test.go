@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyFriendRequests(t *testing.T) {
+	postRequest := func(server Server, cookie *http.Cookie, to string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/my/friend-requests", strings.NewReader("{\"To\": \""+to+"\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyFriendRequests(w, req)
+		return w.Result()
+	}
+
+	t.Run("POST sends the request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().SendRequest("friend")
+
+		res := postRequest(server, cookie, "friend")
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST to someone who blocked the user looks like any other request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().SendRequest("friend").Return(friends.BlockedByError)
+
+		res := postRequest(server, cookie, "friend")
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST to someone the user blocked is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().SendRequest("friend").Return(friends.BlockedError)
+
+		res := postRequest(server, cookie, "friend")
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusForbidden {
+			t.Error("expected status code to be", http.StatusForbidden, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST to a friend conflicts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		friendsApp.EXPECT().SendRequest("friend").Return(friends.AlreadyFriendsError)
+
+		res := postRequest(server, cookie, "friend")
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusConflict {
+			t.Error("expected status code to be", http.StatusConflict, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST to an unknown user is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		friendsApp := mock_main.NewMockFriendsAppInterface(ctrl)
+		server, cookie := newTestServer(t, nil, friendsApp)
+
+		res := postRequest(server, cookie, "stranger")
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_import"
@@ -66,6 +67,19 @@ func (s Server) PostMyHabits(w http.ResponseWriter, r *http.Request) {
 		Name        string
 		Description string
 		Frequency   int
+		// defaults to a week, e.g. {"Unit": "MONTH", "Length": 1}
+		Period habit_share.Period
+		// 0 (Sunday) to 6 (Saturday), leave empty for any day of the week
+		Weekdays []time.Weekday
+		// leave Target empty for habits that are simply done or not
+		Unit    string
+		Target  float64
+		Minimum float64
+		// other users that co-own the habit and each log their own activities
+		Members []string
+		// ALL when every member must do it, ANY when one member is enough.
+		// Defaults to ALL, only used with Members.
+		GroupMode string
 	}{}
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
@@ -81,8 +95,29 @@ func (s Server) PostMyHabits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	habitId, err := app.CreateHabit(newHabit.Name, newHabit.Frequency)
+	// the frequency of a scheduled habit is implied by its weekdays
+	if len(newHabit.Weekdays) > 0 {
+		newHabit.Frequency = len(newHabit.Weekdays)
+	}
+
+	var habitId string
+	if len(newHabit.Members) > 0 {
+		habitId, err = app.CreateGroupHabit(
+			newHabit.Name,
+			newHabit.Frequency,
+			newHabit.Period,
+			newHabit.Members,
+			newHabit.GroupMode,
+		)
+	} else {
+		habitId, err = app.CreateHabit(newHabit.Name, newHabit.Frequency, newHabit.Period)
+	}
 	if err != nil {
+		if err == habit_share.NotFriendsError {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "Members must all be friends")
+			return
+		}
 		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
 			w.WriteHeader(http.StatusBadRequest)
 			fmt.Fprintf(w, "Input was not valid, %s", inputError)
@@ -110,6 +145,36 @@ func (s Server) PostMyHabits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(newHabit.Weekdays) > 0 {
+		err = app.ChangeSchedule(habitId, newHabit.Weekdays)
+	}
+	if err != nil {
+		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Input was not valid, %s", inputError)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong updating schedule of habit")
+		log.Printf("Something has gone wrong updating schedule of habit: %v", err)
+		return
+	}
+
+	if newHabit.Target != 0 {
+		err = app.ChangeTarget(habitId, newHabit.Unit, newHabit.Target, newHabit.Minimum)
+	}
+	if err != nil {
+		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Input was not valid, %s", inputError)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong updating target of habit")
+		log.Printf("Something has gone wrong updating target of habit: %v", err)
+		return
+	}
+
 	w.WriteHeader(http.StatusCreated)
 	fmt.Fprint(w, habitId)
 }
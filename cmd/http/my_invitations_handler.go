@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/auth"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func writeInvitations(w http.ResponseWriter, invitations []habit_share.Invitation) {
+	res, err := json.Marshal(invitations)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+// GetMyInvitations lists the invitations waiting on the current user
+func (s Server) GetMyInvitations(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	invitations, err := app.GetMyInvitations()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetMyInvitations failed")
+		log.Printf("GetMyInvitations failed with %v", err)
+		return
+	}
+
+	writeInvitations(w, invitations)
+}
+
+// PostMyInvitation accepts or declines an invitation sent to the current user
+func (s Server) PostMyInvitation(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 5)
+	if len(splits) != 5 || splits[1] != "my" || splits[2] != "invitations" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Invitation id invalid")
+		return
+	}
+	invitationId := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	switch splits[4] {
+	case "accept":
+		err = app.AcceptInvitation(invitationId)
+	case "decline":
+		err = app.DeclineInvitation(invitationId)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		if err == habit_share.InvitationNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		if err == habit_share.NotFriendsError {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "Not allowed to accept that invitation")
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong answering invitation")
+		log.Printf("Something has gone wrong answering invitation: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s Server) GetSentInvitations(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	invitations, err := app.GetSentInvitations()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetSentInvitations failed")
+		log.Printf("GetSentInvitations failed with %v", err)
+		return
+	}
+
+	writeInvitations(w, invitations)
+}
+
+// PostSentInvitations invites the user with the email to share the habit
+func (s Server) PostSentInvitations(w http.ResponseWriter, r *http.Request) {
+	var err error
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "Content Type is not application/json")
+		return
+	}
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	newInvitation := struct {
+		HabitId string
+		Email   string
+		// VIEW, LOG or EDIT, VIEW when left out
+		Permission string
+	}{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&newInvitation)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		var unmarshalErr *json.UnmarshalTypeError
+		if errors.As(err, &unmarshalErr) {
+			fmt.Fprintf(w, "Bad Request. Wrong Type provided for field: %s", unmarshalErr.Field)
+		} else {
+			fmt.Fprintf(w, "Bad Request: %s", err)
+		}
+		return
+	}
+
+	userId, err := s.AuthDatabase.GetUserIdFromEmail(r.Context(), newInvitation.Email)
+	if err != nil {
+		if err == auth.ErrNotFound {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "No user has that email")
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Unable to send invitation")
+		log.Printf("Unable to look up email: %v", err)
+		return
+	}
+
+	invitationId, err := app.InviteToHabit(
+		newInvitation.HabitId,
+		userId,
+		habit_share.Permission(newInvitation.Permission),
+	)
+	if err != nil {
+		if err == habit_share.HabitNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		if err == habit_share.PermissionDeniedError || err == habit_share.NotFriendsError {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "Not allowed to share that habit with that user")
+			return
+		}
+		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Input was not valid, Permission must be one of VIEW, LOG or EDIT and the habit can't already be shared with that user")
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong sending invitation")
+		log.Printf("Something has gone wrong sending invitation: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, invitationId)
+}
+
+func (s Server) DeleteSentInvitation(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 5)
+	if len(splits) != 5 || splits[1] != "my" || splits[2] != "invitations" || splits[3] != "sent" || splits[4] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Invitation id invalid")
+		return
+	}
+	invitationId := splits[4]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	err = app.CancelInvitation(invitationId)
+	if err != nil {
+		if err == habit_share.InvitationNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong cancelling invitation")
+		log.Printf("Something has gone wrong cancelling invitation: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
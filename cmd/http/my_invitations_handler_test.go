@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyInvitations(t *testing.T) {
+	t.Run("GET returns the invitations waiting on the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetMyInvitations().
+			Return([]habit_share.Invitation{{Id: "mock-id", From: "friend", To: "testUser"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/invitations", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyInvitations(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		invitations := []habit_share.Invitation{}
+		if err := json.NewDecoder(res.Body).Decode(&invitations); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if len(invitations) != 1 || invitations[0].From != "friend" {
+			t.Error("expected the invitation from friend got", invitations)
+		}
+	})
+
+	t.Run("POST /my/invitations/:id/accept accepts it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().AcceptInvitation("mock-id")
+
+		req := httptest.NewRequest(http.MethodPost, "/my/invitations/mock-id/accept", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyInvitation(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNoContent {
+			t.Error("expected status code to be", http.StatusNoContent, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST /my/invitations/:id/accept from someone no longer a friend is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().AcceptInvitation("mock-id").Return(habit_share.NotFriendsError)
+
+		req := httptest.NewRequest(http.MethodPost, "/my/invitations/mock-id/accept", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyInvitation(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusForbidden {
+			t.Error("expected status code to be", http.StatusForbidden, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST /my/invitations/:id/decline declines it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().DeclineInvitation("mock-id")
+
+		req := httptest.NewRequest(http.MethodPost, "/my/invitations/mock-id/decline", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyInvitation(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNoContent {
+			t.Error("expected status code to be", http.StatusNoContent, "got", res.StatusCode)
+		}
+	})
+}
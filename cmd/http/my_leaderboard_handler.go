@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// GetMyLeaderboard ranks the current user and the people they share habits
+// with. ?metric= is SCORE, STREAK or COMPLETION and ?days= is the window.
+func (s Server) GetMyLeaderboard(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	daysString := r.URL.Query().Get("days")
+	if daysString == "" {
+		daysString = strconv.Itoa(habit_share.DefaultLeaderboardDays)
+	}
+	days, err := strconv.Atoi(daysString)
+	if err != nil || days < 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Days query is in incorrect, must be a positive integer")
+		return
+	}
+
+	entries, err := app.GetLeaderboard(r.URL.Query().Get("metric"), days)
+	if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Metric must be SCORE, STREAK or COMPLETION and days at most %d", habit_share.MaxLeaderboardDays)
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetLeaderboard failed")
+		log.Printf("GetLeaderboard failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(entries)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyLeaderboard(t *testing.T) {
+	t.Run("GET passes the metric and default days", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetLeaderboard(habit_share.LeaderboardStreak, habit_share.DefaultLeaderboardDays).
+			Return([]habit_share.LeaderboardEntry{{Rank: 1, User: "friend", Value: 5, Habits: 1}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/leaderboard?metric=STREAK", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyLeaderboard(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		entries := []habit_share.LeaderboardEntry{}
+		if err := json.NewDecoder(res.Body).Decode(&entries); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if len(entries) != 1 || entries[0].User != "friend" {
+			t.Error("expected friend's entry got", entries)
+		}
+	})
+
+	t.Run("GET rejects an unknown metric", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetLeaderboard("LONGEST", 7).
+			Return(nil, &habit_share.InputError{StringToParse: "LONGEST"})
+
+		req := httptest.NewRequest(http.MethodGet, "/my/leaderboard?metric=LONGEST&days=7", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyLeaderboard(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/live"
+)
+
+// comments are sent this often so proxies don't close quiet connections
+const liveKeepAlive = 30 * time.Second
+
+// the event sent instead of a replay when what was missed is no longer kept,
+// the client should fetch everything again
+const liveResync = "RESYNC"
+
+func writeLiveEvent(w http.ResponseWriter, event live.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, event.Data)
+}
+
+// GetMyLive streams changes to the current user's habits, the habits they can
+// see and their todos as Server-Sent Events. Reconnecting with Last-Event-ID,
+// or ?lastEventId= for the first connection, replays what was missed.
+func (s Server) GetMyLive(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	user, err := requestDependencies.AuthService.GetCurrentUser()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetCurrentUser failed")
+		log.Printf("GetCurrentUser failed with %v", err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Streaming is not supported")
+		log.Printf("ResponseWriter does not support flushing")
+		return
+	}
+
+	lastEventIdString := r.Header.Get("Last-Event-ID")
+	if lastEventIdString == "" {
+		lastEventIdString = r.URL.Query().Get("lastEventId")
+	}
+	if lastEventIdString == "" {
+		lastEventIdString = "0"
+	}
+	lastEventId, err := strconv.ParseUint(lastEventIdString, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Last-Event-ID is in incorrect, must be the id of a previous event")
+		return
+	}
+
+	subscription, replay, complete := s.Live.Subscribe(user, lastEventId)
+	defer s.Live.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if !complete {
+		writeLiveEvent(w, live.Event{Id: s.Live.LastId(), Type: liveResync, Data: []byte("{}")})
+	}
+	for _, event := range replay {
+		writeLiveEvent(w, event)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(liveKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// fell too far behind, the client reconnects and catches up
+				return
+			}
+			writeLiveEvent(w, event)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// getLive runs GetMyLive until it has written what it had waiting and
+// returns the response
+func getLive(server Server, cookie *http.Cookie, lastEventId string) (*http.Response, string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// the stream ends as soon as the replay is written
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/my/live", nil).WithContext(ctx)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	if lastEventId != "" {
+		req.Header.Set("Last-Event-ID", lastEventId)
+	}
+	w := httptest.NewRecorder()
+	server.GetMyLive(w, req)
+	res := w.Result()
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	return res, string(body)
+}
+
+func TestHandleMyLive(t *testing.T) {
+	t.Run("GET replays the events after Last-Event-ID", func(t *testing.T) {
+		server, cookie := newTestServer(t, nil, nil)
+		server.Live.Publish([]string{"testUser"}, "HABIT", "first")
+		server.Live.Publish([]string{"friend"}, "HABIT", "not mine")
+		server.Live.Publish([]string{"testUser", "friend"}, "HABIT", "second")
+
+		res, body := getLive(server, cookie, "1")
+
+		if res.StatusCode != http.StatusOK {
+			t.Error("expected status code to be", http.StatusOK, "got", res.StatusCode)
+		}
+		if res.Header.Get("Content-Type") != "text/event-stream" {
+			t.Error("expected an event stream got", res.Header.Get("Content-Type"))
+		}
+		expected := "id: 3\nevent: HABIT\ndata: \"second\"\n\n"
+		if body != expected {
+			t.Errorf("expected only the missed event %q got %q", expected, body)
+		}
+	})
+
+	t.Run("GET asks to resync when the missed events are gone", func(t *testing.T) {
+		server, cookie := newTestServer(t, nil, nil)
+		// the test broker keeps 4 events
+		for i := 0; i < 6; i++ {
+			server.Live.Publish([]string{"testUser"}, "HABIT", i)
+		}
+
+		_, body := getLive(server, cookie, "1")
+
+		expected := "id: 6\nevent: " + liveResync + "\ndata: {}\n\n"
+		if body != expected {
+			t.Errorf("expected %q got %q", expected, body)
+		}
+	})
+
+	t.Run("GET without Last-Event-ID replays nothing", func(t *testing.T) {
+		server, cookie := newTestServer(t, nil, nil)
+		server.Live.Publish([]string{"testUser"}, "HABIT", "first")
+
+		_, body := getLive(server, cookie, "")
+
+		if body != "" {
+			t.Errorf("expected nothing got %q", body)
+		}
+	})
+
+	t.Run("GET rejects a bad Last-Event-ID", func(t *testing.T) {
+		server, cookie := newTestServer(t, nil, nil)
+
+		res, _ := getLive(server, cookie, "abc")
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("GET without a session is forbidden", func(t *testing.T) {
+		server, _ := newTestServer(t, nil, nil)
+
+		res, body := getLive(server, nil, "")
+
+		if res.StatusCode != http.StatusForbidden {
+			t.Error("expected status code to be", http.StatusForbidden, "got", res.StatusCode)
+		}
+		if strings.Contains(body, "event:") {
+			t.Error("expected no events got", body)
+		}
+	})
+}
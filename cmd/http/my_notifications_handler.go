@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// GetMyNotifications lists the current user's notifications newest first.
+// ?unread=true leaves out those already read.
+func (s Server) GetMyNotifications(w http.ResponseWriter, r *http.Request) {
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		limitString = "50"
+	}
+	limit, err := strconv.Atoi(limitString)
+	if err != nil || limit < 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Limit query is in incorrect, must be a positive integer")
+		return
+	}
+
+	notifications, err := app.GetNotifications(limit, r.URL.Query().Get("unread") == "true")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "GetNotifications failed")
+		log.Printf("GetNotifications failed with %v", err)
+		return
+	}
+
+	res, err := json.Marshal(notifications)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
+
+// PostMyNotification marks a notification as read
+func (s Server) PostMyNotification(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 5)
+	if len(splits) != 5 || splits[1] != "my" || splits[2] != "notifications" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Notification id invalid")
+		return
+	}
+	notificationId := splits[3]
+	if splits[4] != "read" {
+		http.NotFound(w, r)
+		return
+	}
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	err = app.MarkNotificationRead(notificationId)
+	if err != nil {
+		if err == habit_share.NotificationNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong marking notification read")
+		log.Printf("Something has gone wrong marking notification read: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s Server) DeleteMyNotification(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "my" || splits[2] != "notifications" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Notification id invalid")
+		return
+	}
+	notificationId := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	err = app.DeleteNotification(notificationId)
+	if err != nil {
+		if err == habit_share.NotificationNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong deleting notification")
+		log.Printf("Something has gone wrong deleting notification: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyNotifications(t *testing.T) {
+	t.Run("GET passes the limit and unread filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetNotifications(5, true).
+			Return([]habit_share.Notification{{Id: "mock-id", User: "testUser"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/notifications?limit=5&unread=true", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMyNotifications(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		notifications := []habit_share.Notification{}
+		if err := json.NewDecoder(res.Body).Decode(&notifications); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if len(notifications) != 1 || notifications[0].Id != "mock-id" {
+			t.Error("expected the notification got", notifications)
+		}
+	})
+
+	t.Run("POST /my/notifications/:id/read marks it read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().MarkNotificationRead("mock-id")
+
+		req := httptest.NewRequest(http.MethodPost, "/my/notifications/mock-id/read", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyNotification(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNoContent {
+			t.Error("expected status code to be", http.StatusNoContent, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("DELETE /my/notifications/:id of someone else's is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().DeleteNotification("mock-id").Return(habit_share.NotificationNotFoundError)
+
+		req := httptest.NewRequest(http.MethodDelete, "/my/notifications/mock-id", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.DeleteMyNotification(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Error("expected status code to be", http.StatusNotFound, "got", res.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMySettings(t *testing.T) {
+	t.Run("GET returns the settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().GetMySettings().
+			Return(habit_share.UserSettings{Timezone: "Australia/Brisbane", DayStartHour: 4}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/my/settings", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.GetMySettings(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		settings := habit_share.UserSettings{}
+		if err := json.NewDecoder(res.Body).Decode(&settings); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if settings.Timezone != "Australia/Brisbane" || settings.DayStartHour != 4 {
+			t.Error("expected the settings got", settings)
+		}
+	})
+
+	t.Run("POST changes the settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().ChangeSettings("Australia/Brisbane", 4)
+
+		req := httptest.NewRequest(http.MethodPost, "/my/settings", strings.NewReader("{\"Timezone\": \"Australia/Brisbane\", \"DayStartHour\": 4}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMySettings(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST rejects an unknown timezone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().ChangeSettings("Nowhere", 0).Return(&habit_share.InputError{StringToParse: "Nowhere"})
+
+		req := httptest.NewRequest(http.MethodPost, "/my/settings", strings.NewReader("{\"Timezone\": \"Nowhere\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMySettings(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/cmd/http/mock"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleMyVacations(t *testing.T) {
+	t.Run("POST creates the vacation between the dates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().CreateVacation(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(start habit_share.Time, end habit_share.Time) (string, error) {
+				if start.Format(habit_share.DateFormat) != "2022-01-01" || end.Format(habit_share.DateFormat) != "2022-01-07" {
+					t.Error("expected 2022-01-01 to 2022-01-07 got", start, end)
+				}
+				return "mock-id", nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/my/vacations", strings.NewReader("{\"Start\": \"2022-01-01\", \"End\": \"2022-01-07\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyVacations(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Error("expected status code to be", http.StatusCreated, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("POST rejects a date in the wrong format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/my/vacations", strings.NewReader("{\"Start\": \"01/01/2022\", \"End\": \"2022-01-07\"}"))
+		req.Header.Add("Content-Type", "application/json")
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.PostMyVacations(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Error("expected status code to be", http.StatusBadRequest, "got", res.StatusCode)
+		}
+	})
+
+	t.Run("DELETE /my/vacations/:id of an unknown vacation is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		habitApp := mock_main.NewMockHabitAppInterface(ctrl)
+		server, cookie := newTestServer(t, habitApp, nil)
+
+		habitApp.EXPECT().DeleteVacation("mock-id").Return(habit_share.VacationNotFoundError)
+
+		req := httptest.NewRequest(http.MethodDelete, "/my/vacations/mock-id", nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.DeleteMyVacation(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Error("expected status code to be", http.StatusNotFound, "got", res.StatusCode)
+		}
+	})
+}
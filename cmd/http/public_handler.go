@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// GetPublicHabit shows the habit a public link is for. It deliberately skips
+// BuildRequestDependenciesOrReject as the token is all that's needed.
+func (s Server) GetPublicHabit(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "public" || splits[2] != "habit" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Token invalid")
+		return
+	}
+	token := splits[3]
+
+	app := s.BuildPublicHabitApp()
+
+	habit, err := app.GetPublicHabit(token)
+	if err != nil {
+		if err == habit_share.PublicLinkNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong getting public habit")
+		log.Printf("Something has gone wrong getting public habit: %v", err)
+		return
+	}
+
+	res, err := json.Marshal(habit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Marshalling failed")
+		log.Printf("Marshalling failed with %v", err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprint(w, string(res))
+}
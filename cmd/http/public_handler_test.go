@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_file"
+)
+
+func TestHandlePublicHabit(t *testing.T) {
+	newPublicServer := func(t *testing.T) Server {
+		db := &habit_share_file.HabitShareFile{
+			Users:  map[string]habit_share_file.User{},
+			Habits: map[string]habit_share_file.HabitJson{},
+		}
+		habitId, err := db.CreateHabit(habit_share.Habit{
+			Owner:       "testUser",
+			Name:        "running",
+			Description: "around the block",
+			Frequency:   3,
+			SharedWith:  map[string]habit_share.Permission{"friend": habit_share.PermissionView},
+		})
+		if err != nil {
+			t.Fatal("failed to create habit:", err)
+		}
+		err = db.CreatePublicLink(habit_share.PublicLink{Token: "mocktoken", HabitId: habitId, Created: time.Now()})
+		if err != nil {
+			t.Fatal("failed to create public link:", err)
+		}
+
+		server, _ := newTestServer(t, nil, nil)
+		server.HabitsDatabase = db
+		server.Scores = habit_share.NewScoreCache()
+		return server
+	}
+
+	t.Run("GET /public/habit/:token works without a session", func(t *testing.T) {
+		server := newPublicServer(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/public/habit/mocktoken", nil)
+		w := httptest.NewRecorder()
+		server.GetPublicHabit(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatal("expected status code to be", http.StatusOK, "got", res.StatusCode)
+		}
+		raw := map[string]interface{}{}
+		if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
+			t.Fatal("expected err to be nil got:", err)
+		}
+		if raw["Name"] != "running" || raw["Description"] != "around the block" {
+			t.Error("expected the habit's name and description got", raw)
+		}
+		for _, field := range []string{"Owner", "SharedWith", "Id"} {
+			if _, ok := raw[field]; ok {
+				t.Error("expected", field, "to be left out got", raw)
+			}
+		}
+	})
+
+	t.Run("GET /public/habit/:token of an unknown token is not found", func(t *testing.T) {
+		server := newPublicServer(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/public/habit/"+strings.Repeat("x", 43), nil)
+		w := httptest.NewRecorder()
+		server.GetPublicHabit(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Error("expected status code to be", http.StatusNotFound, "got", res.StatusCode)
+		}
+	})
+}
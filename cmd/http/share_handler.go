@@ -1,10 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 	"net/http"
 	"strings"
+	"time"
 )
 
 func (s Server) PostUserHabit(w http.ResponseWriter, r *http.Request) {
@@ -38,13 +40,36 @@ func (s Server) PostUserHabit(w http.ResponseWriter, r *http.Request) {
 	}
 	app := reqDeps.HabitApp
 
-	err = app.ShareHabit(habitId, userId)
+	// sharing again with another permission changes what they can do
+	permission := habit_share.Permission(r.URL.Query().Get("permission"))
+	// RFC 3339, the share never expires when left out
+	var expires *time.Time
+	if expiresString := r.URL.Query().Get("expires"); expiresString != "" {
+		parsedExpires, err := time.Parse(time.RFC3339, expiresString)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "expires must be in RFC 3339 format")
+			return
+		}
+		expires = &parsedExpires
+	}
+	err = app.ShareHabit(habitId, userId, permission, expires)
 	if err != nil {
 		if err == habit_share.PermissionDeniedError {
 			w.WriteHeader(http.StatusForbidden)
 			fmt.Fprintf(w, "Not allowed to share that habit")
 			return
 		}
+		if inputError := (*habit_share.InputError)(nil); errors.As(err, &inputError) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "permission must be one of VIEW, LOG or EDIT and expires must be in the future")
+			return
+		}
+		if err == habit_share.NotFriendsError {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "Habits can only be shared with friends")
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, "Unable to share the habit")
 		return
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 )
@@ -27,15 +28,42 @@ func (s Server) GetSharedHabits(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "Limit query is in incorrect, must be an integer")
 	}
 
-	habits, err := app.GetSharedHabits(limit)
+	// muted habits are only listed when asked for
+	var habits []habit_share.Habit
+	if r.URL.Query().Get("muted") == "true" {
+		habits, err = app.GetMutedHabits(limit)
+	} else {
+		habits, err = app.GetSharedHabits(limit)
+	}
 	if err != nil && err != habit_share.UserNotFoundError {
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, "GetMyHabits failed")
 		log.Printf("GetMyHabits failed with %v", err)
 	}
 
+	type sharedHabit struct {
+		habit_share.Habit
+		// null unless the owner is currently on vacation
+		OwnerVacation *habit_share.Vacation
+	}
+	sharedHabits := make([]sharedHabit, 0, len(habits))
+	for _, habit := range habits {
+		vacation, onVacation, err := app.GetOwnerVacation(habit.Id)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "GetOwnerVacation failed")
+			log.Printf("GetOwnerVacation failed with %v", err)
+			return
+		}
+		if onVacation {
+			sharedHabits = append(sharedHabits, sharedHabit{Habit: habit, OwnerVacation: &vacation})
+		} else {
+			sharedHabits = append(sharedHabits, sharedHabit{Habit: habit})
+		}
+	}
+
 	// TODO the SharedWith shouldn't be exposed in what is shared
-	res, err := json.Marshal(habits)
+	res, err := json.Marshal(sharedHabits)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, "Marshalling failed")
@@ -45,3 +73,75 @@ func (s Server) GetSharedHabits(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
 	fmt.Fprint(w, string(res))
 }
+
+// DeleteSharedHabit leaves a habit shared with the current user
+func (s Server) DeleteSharedHabit(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 4)
+	if len(splits) != 4 || splits[1] != "shared" || splits[2] != "habits" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Habit id invalid")
+		return
+	}
+	habitId := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	err = app.LeaveHabit(habitId)
+	if err != nil {
+		if err == habit_share.HabitNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong leaving habit")
+		log.Printf("Something has gone wrong leaving habit: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostSharedHabit mutes or unmutes a habit shared with the current user
+func (s Server) PostSharedHabit(w http.ResponseWriter, r *http.Request) {
+	// first split is an empty string because we start with /
+	splits := strings.SplitN(r.URL.EscapedPath(), "/", 5)
+	if len(splits) != 5 || splits[1] != "shared" || splits[2] != "habits" || splits[3] == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Habit id invalid")
+		return
+	}
+	habitId := splits[3]
+
+	requestDependencies, err := s.BuildRequestDependenciesOrReject(w, r)
+	if err != nil {
+		return
+	}
+	app := requestDependencies.HabitApp
+
+	switch splits[4] {
+	case "mute":
+		err = app.MuteHabit(habitId)
+	case "unmute":
+		err = app.UnmuteHabit(habitId)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		if err == habit_share.HabitNotFoundError {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Something has gone wrong muting habit")
+		log.Printf("Something has gone wrong muting habit: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
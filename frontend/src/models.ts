@@ -13,7 +13,7 @@ export type Habit = {
   Archived: boolean;
 };
 
-export type Status = "SUCCESS" | "MINIMUM" | "NOT_DONE";
+export type Status = "SUCCESS" | "MINIMUM" | "NOT_DONE" | "EXCUSED";
 
 export type Activity = {
   Id: string;
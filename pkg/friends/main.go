@@ -0,0 +1,259 @@
+package friends
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var FriendRequestNotFoundError = errors.New("Friend request could not be found")
+var NotFriendsError = errors.New("Users are not friends")
+var AlreadyFriendsError = errors.New("Users are already friends")
+var BlockedError = errors.New("User is blocked")
+
+// BlockedByError is when the other user blocked the current user. Callers
+// shouldn't pass it on as that would reveal the block.
+var BlockedByError = errors.New("User has blocked the current user")
+
+type InputError struct {
+	Message string
+}
+
+var _ error = (*InputError)(nil)
+
+// Error implements error
+func (e *InputError) Error() string {
+	return fmt.Sprintf("Failed to parse input because: %s", e.Message)
+}
+
+type AuthInterface interface {
+	GetCurrentUser() (string, error)
+}
+
+// HabitsInterface is what happens to habits when a friendship ends
+type HabitsInterface interface {
+	// UnShareFriend stops the current user and friend sharing habits with each other
+	UnShareFriend(friend string) error
+}
+
+type FriendRequest struct {
+	From string
+	To   string
+	Sent time.Time
+}
+
+// Friendships go both ways. Adding or removing one user as a friend of another
+// does the same the other way around.
+type FriendsDatabase interface {
+	// replaces any request already sent from from to to
+	CreateFriendRequest(request FriendRequest) error
+	GetFriendRequest(from string, to string) (FriendRequest, error)
+	// requests sent to user, oldest first
+	GetIncomingRequests(user string) ([]FriendRequest, error)
+	// requests user has sent, oldest first
+	GetOutgoingRequests(user string) ([]FriendRequest, error)
+	DeleteFriendRequest(from string, to string) error
+
+	AddFriends(user string, friend string) error
+	RemoveFriends(user string, friend string) error
+	// sorted
+	GetFriends(user string) ([]string, error)
+	AreFriends(user string, friend string) (bool, error)
+
+	Block(user string, blocked string) error
+	Unblock(user string, blocked string) error
+	// sorted
+	GetBlocked(user string) ([]string, error)
+	// IsBlocked is whether user has blocked other. Anything listing users, like
+	// a search, should leave out those who have blocked whoever is looking.
+	IsBlocked(user string, other string) (bool, error)
+}
+
+type App struct {
+	Db     FriendsDatabase
+	Auth   AuthInterface
+	Habits HabitsInterface
+}
+
+// SendRequest asks to to be friends. If to has already asked the current user
+// they become friends straight away.
+func (a *App) SendRequest(to string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if to == user {
+		return &InputError{Message: "Can't be friends with yourself"}
+	}
+	if friends, err := a.Db.AreFriends(user, to); err != nil {
+		return err
+	} else if friends {
+		return AlreadyFriendsError
+	}
+	if err := a.blockedCheck(user, to); err != nil {
+		return err
+	}
+
+	if _, err := a.Db.GetFriendRequest(to, user); err == nil {
+		return a.AcceptRequest(to)
+	} else if err != FriendRequestNotFoundError {
+		return err
+	}
+
+	return a.Db.CreateFriendRequest(FriendRequest{From: user, To: to, Sent: time.Now()})
+}
+
+// AcceptRequest accepts the request from from to the current user
+func (a *App) AcceptRequest(from string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.Db.GetFriendRequest(from, user); err != nil {
+		return err
+	}
+	if err := a.Db.AddFriends(user, from); err != nil {
+		return err
+	}
+
+	return a.Db.DeleteFriendRequest(from, user)
+}
+
+// DeclineRequest declines the request from from to the current user
+func (a *App) DeclineRequest(from string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	return a.Db.DeleteFriendRequest(from, user)
+}
+
+// CancelRequest takes back the request the current user sent to to
+func (a *App) CancelRequest(to string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	return a.Db.DeleteFriendRequest(user, to)
+}
+
+func (a *App) GetIncomingRequests() ([]FriendRequest, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetIncomingRequests(user)
+}
+
+func (a *App) GetOutgoingRequests() ([]FriendRequest, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetOutgoingRequests(user)
+}
+
+func (a *App) GetFriends() ([]string, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetFriends(user)
+}
+
+// RemoveFriend ends the friendship and any sharing of habits between the two
+func (a *App) RemoveFriend(friend string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if friends, err := a.Db.AreFriends(user, friend); err != nil {
+		return err
+	} else if !friends {
+		return NotFriendsError
+	}
+
+	if err := a.Db.RemoveFriends(user, friend); err != nil {
+		return err
+	}
+
+	return a.Habits.UnShareFriend(friend)
+}
+
+// blockedCheck fails with BlockedError if user has blocked other and
+// BlockedByError if other has blocked user
+func (a *App) blockedCheck(user string, other string) error {
+	if blocked, err := a.Db.IsBlocked(user, other); err != nil {
+		return err
+	} else if blocked {
+		return BlockedError
+	}
+	if blocked, err := a.Db.IsBlocked(other, user); err != nil {
+		return err
+	} else if blocked {
+		return BlockedByError
+	}
+
+	return nil
+}
+
+// Block stops blocked from befriending or sharing with the current user. Any
+// friendship, sharing or requests between the two are ended.
+func (a *App) Block(blocked string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if blocked == user {
+		return &InputError{Message: "Can't block yourself"}
+	}
+
+	if err := a.Db.Block(user, blocked); err != nil {
+		return err
+	}
+
+	for _, request := range []FriendRequest{{From: user, To: blocked}, {From: blocked, To: user}} {
+		err := a.Db.DeleteFriendRequest(request.From, request.To)
+		if err != nil && err != FriendRequestNotFoundError {
+			return err
+		}
+	}
+
+	if friends, err := a.Db.AreFriends(user, blocked); err != nil {
+		return err
+	} else if friends {
+		if err := a.Db.RemoveFriends(user, blocked); err != nil {
+			return err
+		}
+	}
+
+	// there may be sharing left from before friends were needed to share
+	return a.Habits.UnShareFriend(blocked)
+}
+
+func (a *App) Unblock(blocked string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	return a.Db.Unblock(user, blocked)
+}
+
+func (a *App) GetBlocked() ([]string, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetBlocked(user)
+}
@@ -0,0 +1,110 @@
+package friends_file
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
+)
+
+func newTestFile(t *testing.T) *FriendsFile {
+	friendsFile := &FriendsFile{
+		Users:    generateTestData(),
+		filename: t.TempDir() + "/output.json",
+		fileLock: &sync.Mutex{},
+	}
+	if err := friendsFile.write(); err != nil {
+		t.Fatal("expected error to be nil got:", err)
+	}
+	return friendsFile
+}
+
+func TestFriends(t *testing.T) {
+	t.Run("should send request both ways", func(t *testing.T) {
+		friendsFile := newTestFile(t)
+		request := friends.FriendRequest{From: "testUser2", To: "testUser3", Sent: time.Now()}
+
+		if err := friendsFile.CreateFriendRequest(request); err != nil {
+			t.Fatal("expected error to be nil got:", err)
+		}
+
+		incoming, err := friendsFile.GetIncomingRequests("testUser3")
+		if err != nil || len(incoming) != 1 || incoming[0].From != "testUser2" {
+			t.Error("expected request from testUser2 got:", incoming, err)
+		}
+		outgoing, err := friendsFile.GetOutgoingRequests("testUser2")
+		if err != nil || len(outgoing) != 1 || outgoing[0].To != "testUser3" {
+			t.Error("expected request to testUser3 got:", outgoing, err)
+		}
+	})
+
+	t.Run("should delete request", func(t *testing.T) {
+		friendsFile := newTestFile(t)
+
+		if err := friendsFile.DeleteFriendRequest("testUser3", "testUser1"); err != nil {
+			t.Fatal("expected error to be nil got:", err)
+		}
+		if _, err := friendsFile.GetFriendRequest("testUser3", "testUser1"); err != friends.FriendRequestNotFoundError {
+			t.Error("expected FriendRequestNotFoundError got:", err)
+		}
+		if incoming, _ := friendsFile.GetIncomingRequests("testUser1"); len(incoming) != 0 {
+			t.Error("expected no incoming requests got:", incoming)
+		}
+
+		if err := friendsFile.DeleteFriendRequest("testUser3", "testUser1"); err != friends.FriendRequestNotFoundError {
+			t.Error("expected FriendRequestNotFoundError got:", err)
+		}
+	})
+
+	t.Run("should add and remove friends both ways", func(t *testing.T) {
+		friendsFile := newTestFile(t)
+
+		if err := friendsFile.AddFriends("testUser3", "testUser1"); err != nil {
+			t.Fatal("expected error to be nil got:", err)
+		}
+		userFriends, err := friendsFile.GetFriends("testUser1")
+		if err != nil || len(userFriends) != 2 || userFriends[0] != "testUser2" || userFriends[1] != "testUser3" {
+			t.Error("expected testUser2 and testUser3 got:", userFriends, err)
+		}
+
+		if err := friendsFile.RemoveFriends("testUser2", "testUser1"); err != nil {
+			t.Fatal("expected error to be nil got:", err)
+		}
+		if ok, _ := friendsFile.AreFriends("testUser1", "testUser2"); ok {
+			t.Error("expected testUser1 and testUser2 to no longer be friends")
+		}
+		if err := friendsFile.RemoveFriends("testUser2", "testUser1"); err != friends.NotFriendsError {
+			t.Error("expected NotFriendsError got:", err)
+		}
+	})
+
+	t.Run("should have no friends for unknown user", func(t *testing.T) {
+		friendsFile := newTestFile(t)
+
+		userFriends, err := friendsFile.GetFriends("unknown")
+		if err != nil || len(userFriends) != 0 {
+			t.Error("expected no friends got:", userFriends, err)
+		}
+	})
+	t.Run("should block one way", func(t *testing.T) {
+		friendsFile := newTestFile(t)
+
+		if err := friendsFile.Block("testUser1", "testUser3"); err != nil {
+			t.Fatal("expected error to be nil got:", err)
+		}
+		if ok, _ := friendsFile.IsBlocked("testUser1", "testUser3"); !ok {
+			t.Error("expected testUser1 to have blocked testUser3")
+		}
+		if ok, _ := friendsFile.IsBlocked("testUser3", "testUser1"); ok {
+			t.Error("expected testUser3 to not have blocked testUser1")
+		}
+
+		if err := friendsFile.Unblock("testUser1", "testUser3"); err != nil {
+			t.Fatal("expected error to be nil got:", err)
+		}
+		if blocked, _ := friendsFile.GetBlocked("testUser1"); len(blocked) != 0 {
+			t.Error("expected nobody to be blocked got:", blocked)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package friends_file
+
+import (
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
+)
+
+func generateTestData() map[string]UserFriends {
+	request := friends.FriendRequest{
+		From: "testUser3",
+		To:   "testUser1",
+		Sent: time.Date(2022, time.May, 25, 0, 0, 0, 0, time.UTC),
+	}
+
+	testUsers := map[string]UserFriends{
+		"testUser1": {
+			Friends:  map[string]struct{}{"testUser2": {}},
+			Incoming: map[string]friends.FriendRequest{"testUser3": request},
+			Outgoing: map[string]friends.FriendRequest{},
+		},
+		"testUser2": {
+			Friends:  map[string]struct{}{"testUser1": {}},
+			Incoming: map[string]friends.FriendRequest{},
+			Outgoing: map[string]friends.FriendRequest{},
+		},
+		"testUser3": {
+			Friends:  map[string]struct{}{},
+			Incoming: map[string]friends.FriendRequest{},
+			Outgoing: map[string]friends.FriendRequest{"testUser1": request},
+		},
+	}
+
+	return testUsers
+}
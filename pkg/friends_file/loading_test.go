@@ -0,0 +1,66 @@
+package friends_file
+
+import (
+	"sync"
+	"testing"
+)
+
+var inputJson = "input.json"
+
+func TestIo(t *testing.T) {
+	t.Run("should read from file that doesn't exist", func(t *testing.T) {
+		friendsFile := FriendsFile{
+			filename: "doesn't exist",
+			fileLock: &sync.Mutex{},
+		}
+
+		err := friendsFile.read()
+		if err != nil {
+			t.Error("expected error to be nil got: ", err)
+		}
+	})
+
+	t.Run("should read from a file", func(t *testing.T) {
+		friendsFile := FriendsFile{
+			filename: inputJson,
+			fileLock: &sync.Mutex{},
+		}
+
+		err := friendsFile.read()
+		if err != nil {
+			t.Error("expected error to be nil got: ", err)
+		}
+
+		if ok, _ := friendsFile.AreFriends("testUser2", "testUser1"); !ok {
+			t.Errorf("Failed to correctly parse the input json %+v", friendsFile)
+		}
+	})
+
+	t.Run("should read from file that is written", func(t *testing.T) {
+		tempDir := t.TempDir()
+		friendsFile := FriendsFile{
+			Users:    generateTestData(),
+			filename: tempDir + "/output.json",
+			fileLock: &sync.Mutex{},
+		}
+
+		err := friendsFile.write()
+		if err != nil {
+			t.Error("expected error to be nil got: ", err)
+		}
+
+		friendsFile2 := FriendsFile{
+			filename: tempDir + "/output.json",
+			fileLock: &sync.Mutex{},
+		}
+
+		err = friendsFile2.read()
+		if err != nil {
+			t.Error("expected error to be nil got: ", err)
+		}
+
+		if _, err := friendsFile2.GetFriendRequest("testUser3", "testUser1"); err != nil {
+			t.Errorf("Failed to correctly parse the written json %+v", friendsFile2)
+		}
+	})
+}
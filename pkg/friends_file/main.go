@@ -0,0 +1,330 @@
+package friends_file
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/friends"
+)
+
+// TTL in seconds
+const cacheTtl = 10
+
+type UserFriends struct {
+	Friends map[string]struct{}
+	// keyed by who the request is from
+	Incoming map[string]friends.FriendRequest
+	// keyed by who the request is to
+	Outgoing map[string]friends.FriendRequest
+	Blocked  map[string]struct{}
+}
+
+func newUserFriends() UserFriends {
+	return UserFriends{
+		Friends:  make(map[string]struct{}),
+		Incoming: make(map[string]friends.FriendRequest),
+		Outgoing: make(map[string]friends.FriendRequest),
+		Blocked:  make(map[string]struct{}),
+	}
+}
+
+type FriendsFile struct {
+	Users    map[string]UserFriends
+	filename string
+	fileLock *sync.Mutex
+	lastRead time.Time
+}
+
+var _ friends.FriendsDatabase = (*FriendsFile)(nil)
+
+func FriendsFromFile(filename string) (*FriendsFile, error) {
+	var friendsFile FriendsFile
+	friendsFile.filename = filename
+	friendsFile.fileLock = &sync.Mutex{}
+
+	err := friendsFile.read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &friendsFile, nil
+}
+
+func (a *FriendsFile) read() error {
+	a.fileLock.Lock()
+	defer a.fileLock.Unlock()
+	if a.filename != "" && time.Since(a.lastRead) > time.Duration(cacheTtl*float64(time.Second)) {
+		content, err := os.ReadFile(a.filename)
+		a.lastRead = time.Now()
+		if err != nil || len(content) == 0 {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			// file does not exist or got removed
+			a.Users = make(map[string]UserFriends)
+			return nil
+		}
+		err = json.Unmarshal(content, a)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if a.Users == nil {
+		a.Users = make(map[string]UserFriends)
+	}
+	return nil
+}
+
+func (a *FriendsFile) write() error {
+	a.fileLock.Lock()
+	defer a.fileLock.Unlock()
+	if a.filename != "" {
+		file, err := os.OpenFile(a.filename, os.O_TRUNC|os.O_WRONLY|os.O_CREATE, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		jsonString, err := json.MarshalIndent(a, "", " ")
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(jsonString)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// user returns the friends of userId creating them if they don't exist yet
+func (a *FriendsFile) user(userId string) UserFriends {
+	user, ok := a.Users[userId]
+	if !ok {
+		return newUserFriends()
+	}
+	// older files may be missing some of these
+	if user.Friends == nil {
+		user.Friends = make(map[string]struct{})
+	}
+	if user.Incoming == nil {
+		user.Incoming = make(map[string]friends.FriendRequest)
+	}
+	if user.Outgoing == nil {
+		user.Outgoing = make(map[string]friends.FriendRequest)
+	}
+	if user.Blocked == nil {
+		user.Blocked = make(map[string]struct{})
+	}
+	return user
+}
+
+func sortRequests(requests []friends.FriendRequest) {
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Sent.Before(requests[j].Sent)
+	})
+}
+
+// CreateFriendRequest implements friends.FriendsDatabase
+func (a *FriendsFile) CreateFriendRequest(request friends.FriendRequest) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	from := a.user(request.From)
+	from.Outgoing[request.To] = request
+	a.Users[request.From] = from
+
+	to := a.user(request.To)
+	to.Incoming[request.From] = request
+	a.Users[request.To] = to
+
+	return a.write()
+}
+
+// GetFriendRequest implements friends.FriendsDatabase
+func (a *FriendsFile) GetFriendRequest(from string, to string) (friends.FriendRequest, error) {
+	if err := a.read(); err != nil {
+		return friends.FriendRequest{}, err
+	}
+
+	request, ok := a.user(from).Outgoing[to]
+	if !ok {
+		return friends.FriendRequest{}, friends.FriendRequestNotFoundError
+	}
+
+	return request, nil
+}
+
+// GetIncomingRequests implements friends.FriendsDatabase
+func (a *FriendsFile) GetIncomingRequests(user string) ([]friends.FriendRequest, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	requests := make([]friends.FriendRequest, 0)
+	for _, request := range a.user(user).Incoming {
+		requests = append(requests, request)
+	}
+	sortRequests(requests)
+
+	return requests, nil
+}
+
+// GetOutgoingRequests implements friends.FriendsDatabase
+func (a *FriendsFile) GetOutgoingRequests(user string) ([]friends.FriendRequest, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	requests := make([]friends.FriendRequest, 0)
+	for _, request := range a.user(user).Outgoing {
+		requests = append(requests, request)
+	}
+	sortRequests(requests)
+
+	return requests, nil
+}
+
+// DeleteFriendRequest implements friends.FriendsDatabase
+func (a *FriendsFile) DeleteFriendRequest(from string, to string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	fromUser := a.user(from)
+	if _, ok := fromUser.Outgoing[to]; !ok {
+		return friends.FriendRequestNotFoundError
+	}
+	delete(fromUser.Outgoing, to)
+	a.Users[from] = fromUser
+
+	toUser := a.user(to)
+	delete(toUser.Incoming, from)
+	a.Users[to] = toUser
+
+	return a.write()
+}
+
+// AddFriends implements friends.FriendsDatabase
+func (a *FriendsFile) AddFriends(userId string, friend string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user := a.user(userId)
+	user.Friends[friend] = struct{}{}
+	a.Users[userId] = user
+
+	other := a.user(friend)
+	other.Friends[userId] = struct{}{}
+	a.Users[friend] = other
+
+	return a.write()
+}
+
+// RemoveFriends implements friends.FriendsDatabase
+func (a *FriendsFile) RemoveFriends(userId string, friend string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user := a.user(userId)
+	if _, ok := user.Friends[friend]; !ok {
+		return friends.NotFriendsError
+	}
+	delete(user.Friends, friend)
+	a.Users[userId] = user
+
+	other := a.user(friend)
+	delete(other.Friends, userId)
+	a.Users[friend] = other
+
+	return a.write()
+}
+
+// GetFriends implements friends.FriendsDatabase
+func (a *FriendsFile) GetFriends(userId string) ([]string, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	userFriends := make([]string, 0)
+	for friend := range a.user(userId).Friends {
+		userFriends = append(userFriends, friend)
+	}
+	sort.Strings(userFriends)
+
+	return userFriends, nil
+}
+
+// AreFriends implements friends.FriendsDatabase
+func (a *FriendsFile) AreFriends(userId string, friend string) (bool, error) {
+	if err := a.read(); err != nil {
+		return false, err
+	}
+
+	_, ok := a.user(userId).Friends[friend]
+	return ok, nil
+}
+
+// Block implements friends.FriendsDatabase
+func (a *FriendsFile) Block(userId string, blocked string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user := a.user(userId)
+	user.Blocked[blocked] = struct{}{}
+	a.Users[userId] = user
+
+	return a.write()
+}
+
+// Unblock implements friends.FriendsDatabase
+func (a *FriendsFile) Unblock(userId string, blocked string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user := a.user(userId)
+	delete(user.Blocked, blocked)
+	a.Users[userId] = user
+
+	return a.write()
+}
+
+// GetBlocked implements friends.FriendsDatabase
+func (a *FriendsFile) GetBlocked(userId string) ([]string, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	blocked := make([]string, 0)
+	for other := range a.user(userId).Blocked {
+		blocked = append(blocked, other)
+	}
+	sort.Strings(blocked)
+
+	return blocked, nil
+}
+
+// IsBlocked implements friends.FriendsDatabase
+func (a *FriendsFile) IsBlocked(userId string, other string) (bool, error) {
+	if err := a.read(); err != nil {
+		return false, err
+	}
+
+	_, ok := a.user(userId).Blocked[other]
+	return ok, nil
+}
@@ -0,0 +1,343 @@
+package habit_share
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+)
+
+var ChallengeNotFoundError = errors.New("Challenge could not be found")
+var ChallengeOverError = errors.New("Challenge is over")
+
+// Challenge is a habit everyone taking part does from Start to End. Joining
+// gives the participant their own habit linked to the challenge which they
+// log like any other.
+type Challenge struct {
+	Id          string
+	Name        string
+	Description string
+	Organizer   string
+	// first and last days of the challenge in the organizer's timezone
+	Start Time
+	End   Time
+	// given to the habit each participant gets on joining
+	Frequency int
+	Period    Period
+	// who may join including the Organizer, sorted
+	Participants []string
+	// id of the linked habit of each participant who joined
+	Habits map[string]string
+	// the final standings once the challenge is over, otherwise the standings
+	// so far when returned by GetChallenge
+	Standings []Standing
+	Finished  bool
+}
+
+// Standing is how a participant who joined did over the challenge
+type Standing struct {
+	// participants with the same CompletionRate and Successes share a Rank,
+	// 1 is best
+	Rank        int
+	Participant string
+	Successes   int
+	// fraction of what was required from Start that was done, only finished
+	// periods count
+	CompletionRate float64
+}
+
+func (c Challenge) HasParticipant(user string) bool {
+	i := sort.SearchStrings(c.Participants, user)
+	return i < len(c.Participants) && c.Participants[i] == user
+}
+
+// CreateChallenge creates a challenge organized by the current user which
+// they join straight away. participants must be friends of the organizer.
+func (a *App) CreateChallenge(
+	name string,
+	description string,
+	start Time,
+	end Time,
+	frequency int,
+	period Period,
+	participants []string,
+) (string, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+
+	if period != (Period{}) {
+		period, err = NewPeriod(period.Unit, period.Length)
+		if err != nil {
+			return "", err
+		}
+	}
+	if frequency < 1 || frequency > period.MaxFrequency() {
+		return "", &InputError{StringToParse: fmt.Sprint(frequency)}
+	}
+	today, err := a.todayFor(user)
+	if err != nil {
+		return "", err
+	}
+	if end.Before(start.Time) || end.Before(today.Time) {
+		return "", &InputError{StringToParse: fmt.Sprintf("start=%s end=%s", start, end)}
+	}
+
+	unique := map[string]struct{}{user: {}}
+	for _, participant := range participants {
+		if _, ok := unique[participant]; ok {
+			continue
+		}
+		if err := a.friendCheck(participant); err != nil {
+			return "", err
+		}
+		unique[participant] = struct{}{}
+	}
+	sortedParticipants := make([]string, 0, len(unique))
+	for participant := range unique {
+		sortedParticipants = append(sortedParticipants, participant)
+	}
+	sort.Strings(sortedParticipants)
+
+	id, err := a.Db.CreateChallenge(Challenge{
+		Name:         name,
+		Description:  description,
+		Organizer:    user,
+		Start:        start,
+		End:          end,
+		Frequency:    frequency,
+		Period:       period,
+		Participants: sortedParticipants,
+		Habits:       map[string]string{},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := a.JoinChallenge(id); err != nil {
+		return id, err
+	}
+	return id, nil
+}
+
+// participantCheck fetches the challenge if the current user may take part
+func (a *App) participantCheck(id string) (string, Challenge, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return "", Challenge{}, err
+	}
+
+	challenge, err := a.Db.GetChallenge(id)
+	if err != nil {
+		return "", Challenge{}, err
+	}
+	if !challenge.HasParticipant(user) {
+		// don't let on the challenge exists
+		return "", Challenge{}, ChallengeNotFoundError
+	}
+
+	return user, challenge, nil
+}
+
+// GetChallenge returns the challenge with its standings, finishing it if it's
+// over
+func (a *App) GetChallenge(id string) (Challenge, error) {
+	_, challenge, err := a.participantCheck(id)
+	if err != nil {
+		return Challenge{}, err
+	}
+	if challenge.Finished {
+		return challenge, nil
+	}
+
+	over, err := a.challengeOver(challenge)
+	if err != nil {
+		return Challenge{}, err
+	}
+	if over {
+		return a.finishChallenge(challenge)
+	}
+
+	challenge.Standings, err = a.standings(challenge)
+	if err != nil {
+		return Challenge{}, err
+	}
+	return challenge, nil
+}
+
+// GetMyChallenges lists the challenges the current user may take part in,
+// sorted by Start. Standings are only included for finished challenges.
+func (a *App) GetMyChallenges() ([]Challenge, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetChallenges(user)
+}
+
+// JoinChallenge creates the current user's habit for the challenge and returns
+// its id. A participant who deleted their habit may join again while the
+// challenge is on, starting over with a new habit.
+func (a *App) JoinChallenge(id string) (string, error) {
+	user, challenge, err := a.participantCheck(id)
+	if err != nil {
+		return "", err
+	}
+	if over, err := a.challengeOver(challenge); err != nil {
+		return "", err
+	} else if challenge.Finished || over {
+		return "", ChallengeOverError
+	}
+	previous, joined := challenge.Habits[user]
+	if joined {
+		if _, err := a.Db.GetHabit(previous); err == nil {
+			return "", &InputError{StringToParse: id}
+		} else if err != HabitNotFoundError {
+			return "", err
+		}
+	}
+
+	habitId, err := a.createHabit(Habit{
+		Owner:       user,
+		Name:        challenge.Name,
+		Description: challenge.Description,
+		Frequency:   challenge.Frequency,
+		Period:      challenge.Period,
+		ChallengeId: challenge.Id,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := a.Db.LinkChallengeHabit(id, user, previous, habitId); err != nil {
+		// joined at the same time from elsewhere so this habit isn't needed
+		if err := a.DeleteHabit(habitId); err != nil {
+			log.Printf("Failed to delete unlinked habit %s: %v", habitId, err)
+		}
+		return "", err
+	}
+
+	return habitId, nil
+}
+
+// FinishEndedChallenges takes the final standings of every challenge that is
+// over and returns how many there were. It doesn't need a current user.
+func (a *App) FinishEndedChallenges() (int, error) {
+	challenges, err := a.Db.GetUnfinishedChallenges()
+	if err != nil {
+		return 0, err
+	}
+
+	finished := 0
+	for _, challenge := range challenges {
+		over, err := a.challengeOver(challenge)
+		if err != nil {
+			return finished, err
+		}
+		if !over {
+			continue
+		}
+		if _, err := a.finishChallenge(challenge); err != nil {
+			return finished, err
+		}
+		finished++
+	}
+
+	return finished, nil
+}
+
+func (a *App) challengeOver(challenge Challenge) (bool, error) {
+	today, err := a.todayFor(challenge.Organizer)
+	if err != nil {
+		return false, err
+	}
+	return today.After(challenge.End.Time), nil
+}
+
+func (a *App) finishChallenge(challenge Challenge) (Challenge, error) {
+	standings, err := a.standings(challenge)
+	if err != nil {
+		return Challenge{}, err
+	}
+
+	challenge.Standings = standings
+	challenge.Finished = true
+	if err := a.Db.SetChallenge(challenge.Id, challenge); err != nil {
+		return Challenge{}, err
+	}
+	return challenge, nil
+}
+
+// standings ranks the participants who joined by what they logged from Start
+// to End. Participants who deleted their habit are left with nothing.
+func (a *App) standings(challenge Challenge) ([]Standing, error) {
+	today, err := a.todayFor(challenge.Organizer)
+	if err != nil {
+		return nil, err
+	}
+	end := challenge.End.AddDate(0, 0, 1)
+	// evaluating up to the day after End finishes the last day's period
+	until := today.Time
+	if until.After(end) {
+		until = end
+	}
+
+	standings := make([]Standing, 0, len(challenge.Habits))
+	for _, participant := range challenge.Participants {
+		habitId, ok := challenge.Habits[participant]
+		if !ok {
+			continue
+		}
+		standing := Standing{Participant: participant}
+
+		habit, err := a.Db.GetHabit(habitId)
+		if err == HabitNotFoundError {
+			standings = append(standings, standing)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		// judged by what the challenge asks for as the participant may have
+		// changed their habit since joining
+		habit.Frequency = challenge.Frequency
+		habit.Period = challenge.Period
+		habit.Schedule = Schedule{}
+		habit.ScheduleHistory = nil
+		vacations, err := a.Db.GetVacations(participant)
+		if err != nil {
+			return nil, err
+		}
+		activities, _, err := a.Db.GetActivities(habitId, challenge.Start, Time{Time: end}, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+
+		periods := evaluatePeriodsFrom(habit, activities, vacations, challenge.Start.Time, until)
+		for _, period := range periods {
+			standing.Successes += period.Successes
+		}
+		standing.CompletionRate = completionRate(periods, challenge.Start.Time)
+		standings = append(standings, standing)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].CompletionRate != standings[j].CompletionRate {
+			return standings[i].CompletionRate > standings[j].CompletionRate
+		}
+		return standings[i].Successes > standings[j].Successes
+	})
+	for i := range standings {
+		if i > 0 &&
+			standings[i].CompletionRate == standings[i-1].CompletionRate &&
+			standings[i].Successes == standings[i-1].Successes {
+			standings[i].Rank = standings[i-1].Rank
+		} else {
+			standings[i].Rank = i + 1
+		}
+	}
+
+	return standings, nil
+}
@@ -0,0 +1,229 @@
+package habit_share_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_file"
+)
+
+// joiningDb joins the challenge elsewhere just before the habit is created, as
+// if two joins happened at once
+type joiningDb struct {
+	*habit_share_file.HabitShareFile
+	join func()
+}
+
+func (d joiningDb) CreateHabit(newHabit habit_share.Habit) (string, error) {
+	d.join()
+	return d.HabitShareFile.CreateHabit(newHabit)
+}
+
+func TestChallenges(t *testing.T) {
+	daily := habit_share.Period{Unit: habit_share.PeriodDay, Length: 1}
+	newApps := func(t *testing.T) (app *habit_share.App, friendApp *habit_share.App, today habit_share.Time) {
+		db := newTestDb()
+		app = &habit_share.App{Db: db, Auth: testAuth{}}
+		friendApp = &habit_share.App{Db: db, Auth: otherAuth{}}
+		today = habit_share.UserSettings{}.Today()
+		return app, friendApp, today
+	}
+	daysAgo := func(today habit_share.Time, days int) habit_share.Time {
+		return habit_share.Time{Time: today.AddDate(0, 0, -days)}
+	}
+
+	t.Run("should give everyone who joins a linked habit", func(t *testing.T) {
+		app, friendApp, today := newApps(t)
+
+		id, err := app.CreateChallenge("no sugar", "", today, daysAgo(today, -29), 1, daily, []string{"friend"})
+		if err != nil {
+			t.Fatal("CreateChallenge returned error unexpectedly:", err)
+		}
+		habitId, err := friendApp.JoinChallenge(id)
+		if err != nil {
+			t.Fatal("JoinChallenge returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.JoinChallenge(id); err == nil {
+			t.Error("expected joining twice to fail")
+		}
+
+		for _, a := range []*habit_share.App{app, friendApp} {
+			habits, _ := a.GetMyHabits(10, false)
+			if len(habits) != 1 || habits[0].ChallengeId != id || habits[0].Name != "no sugar" {
+				t.Errorf("expected a habit linked to the challenge got %v", habits)
+			}
+		}
+
+		if _, err := friendApp.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		challenge, err := app.GetChallenge(id)
+		if err != nil {
+			t.Fatal("GetChallenge returned error unexpectedly:", err)
+		}
+		if challenge.Finished || len(challenge.Standings) != 2 || challenge.Standings[0].Participant != "friend" {
+			t.Errorf("expected friend to lead got %+v", challenge.Standings)
+		}
+	})
+
+	t.Run("should keep the final standings once over", func(t *testing.T) {
+		app, friendApp, today := newApps(t)
+
+		id, err := app.CreateChallenge("no sugar", "", daysAgo(today, 3), today, 1, daily, []string{"friend"})
+		if err != nil {
+			t.Fatal("CreateChallenge returned error unexpectedly:", err)
+		}
+		friendHabitId, _ := friendApp.JoinChallenge(id)
+		habits, _ := app.GetMyHabits(10, false)
+		for days := 3; days >= 1; days-- {
+			if _, err := app.CreateActivity(habits[0].Id, daysAgo(today, days), habit_share.ActivitySuccess, 0); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+		if _, err := friendApp.CreateActivity(friendHabitId, daysAgo(today, 3), habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		// stands in for the challenge ending yesterday
+		challenge, _ := app.Db.GetChallenge(id)
+		challenge.End = daysAgo(today, 1)
+		if err := app.Db.SetChallenge(id, challenge); err != nil {
+			t.Fatal("SetChallenge returned error unexpectedly:", err)
+		}
+
+		if finished, err := app.FinishEndedChallenges(); err != nil || finished != 1 {
+			t.Fatalf("expected 1 challenge to finish got %d %v", finished, err)
+		}
+		// logging afterwards doesn't change the snapshot
+		if _, err := friendApp.CreateActivity(friendHabitId, daysAgo(today, 2), habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		challenge, err = friendApp.GetChallenge(id)
+		if err != nil {
+			t.Fatal("GetChallenge returned error unexpectedly:", err)
+		}
+		expected := []habit_share.Standing{
+			{Rank: 1, Participant: "testUser", Successes: 3, CompletionRate: 1},
+			{Rank: 2, Participant: "friend", Successes: 1, CompletionRate: 1.0 / 3},
+		}
+		if !challenge.Finished || len(challenge.Standings) != len(expected) {
+			t.Fatalf("expected %v got %+v", expected, challenge)
+		}
+		for i := range expected {
+			if challenge.Standings[i] != expected[i] {
+				t.Errorf("expected %v got %v", expected[i], challenge.Standings[i])
+			}
+		}
+		if _, err := friendApp.JoinChallenge(id); err != habit_share.ChallengeOverError {
+			t.Error("expected ChallengeOverError got:", err)
+		}
+	})
+
+	t.Run("should judge by the challenge not the linked habit", func(t *testing.T) {
+		app, friendApp, today := newApps(t)
+
+		id, err := app.CreateChallenge("no sugar", "", daysAgo(today, 3), today, 1, daily, []string{"friend"})
+		if err != nil {
+			t.Fatal("CreateChallenge returned error unexpectedly:", err)
+		}
+		friendHabitId, _ := friendApp.JoinChallenge(id)
+		// stands in for friend making the habit due once a week from the start
+		habit, _ := app.Db.GetHabit(friendHabitId)
+		habit.Period = habit_share.Period{Unit: habit_share.PeriodWeek, Length: 1}
+		habit.Schedule = habit_share.Schedule{Weekdays: []time.Weekday{daysAgo(today, 3).Weekday()}}
+		if err := app.Db.SetHabit(friendHabitId, habit); err != nil {
+			t.Fatal("SetHabit returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.CreateActivity(friendHabitId, daysAgo(today, 3), habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		challenge, _ := app.Db.GetChallenge(id)
+		challenge.End = daysAgo(today, 1)
+		if err := app.Db.SetChallenge(id, challenge); err != nil {
+			t.Fatal("SetChallenge returned error unexpectedly:", err)
+		}
+
+		challenge, err = friendApp.GetChallenge(id)
+		if err != nil {
+			t.Fatal("GetChallenge returned error unexpectedly:", err)
+		}
+		expected := habit_share.Standing{Rank: 1, Participant: "friend", Successes: 1, CompletionRate: 1.0 / 3}
+		if len(challenge.Standings) != 2 || challenge.Standings[0] != expected {
+			t.Errorf("expected %v got %+v", expected, challenge.Standings)
+		}
+	})
+
+	t.Run("should hide challenges from those not taking part", func(t *testing.T) {
+		app, friendApp, today := newApps(t)
+
+		id, err := app.CreateChallenge("no sugar", "", today, today, 1, daily, nil)
+		if err != nil {
+			t.Fatal("CreateChallenge returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.GetChallenge(id); err != habit_share.ChallengeNotFoundError {
+			t.Error("expected ChallengeNotFoundError got:", err)
+		}
+		if _, err := friendApp.JoinChallenge(id); err != habit_share.ChallengeNotFoundError {
+			t.Error("expected ChallengeNotFoundError got:", err)
+		}
+	})
+
+	t.Run("should only link one habit when joining at once", func(t *testing.T) {
+		app, friendApp, today := newApps(t)
+
+		id, err := app.CreateChallenge("no sugar", "", today, daysAgo(today, -29), 1, daily, []string{"friend"})
+		if err != nil {
+			t.Fatal("CreateChallenge returned error unexpectedly:", err)
+		}
+		var joinedId string
+		racingApp := &habit_share.App{Auth: otherAuth{}, Db: joiningDb{
+			HabitShareFile: friendApp.Db.(*habit_share_file.HabitShareFile),
+			join: func() {
+				joinedId, err = friendApp.JoinChallenge(id)
+				if err != nil {
+					t.Fatal("JoinChallenge returned error unexpectedly:", err)
+				}
+			},
+		}}
+
+		if _, err := racingApp.JoinChallenge(id); err == nil {
+			t.Error("expected the second join to fail")
+		}
+
+		challenge, _ := app.GetChallenge(id)
+		if challenge.Habits["friend"] != joinedId {
+			t.Errorf("expected the first join's habit to be linked got %v", challenge.Habits)
+		}
+		if habits, _ := friendApp.GetMyHabits(10, false); len(habits) != 1 || habits[0].Id != joinedId {
+			t.Errorf("expected only the linked habit got %v", habits)
+		}
+	})
+
+	t.Run("should let a participant who deleted their habit join again", func(t *testing.T) {
+		app, friendApp, today := newApps(t)
+
+		id, err := app.CreateChallenge("no sugar", "", today, daysAgo(today, -29), 1, daily, []string{"friend"})
+		if err != nil {
+			t.Fatal("CreateChallenge returned error unexpectedly:", err)
+		}
+		habitId, err := friendApp.JoinChallenge(id)
+		if err != nil {
+			t.Fatal("JoinChallenge returned error unexpectedly:", err)
+		}
+		if err := friendApp.DeleteHabit(habitId); err != nil {
+			t.Fatal("DeleteHabit returned error unexpectedly:", err)
+		}
+
+		rejoinedId, err := friendApp.JoinChallenge(id)
+		if err != nil {
+			t.Fatal("JoinChallenge returned error unexpectedly:", err)
+		}
+		challenge, _ := app.GetChallenge(id)
+		if rejoinedId == habitId || challenge.Habits["friend"] != rejoinedId {
+			t.Errorf("expected the new habit %s to be linked got %v", rejoinedId, challenge.Habits)
+		}
+	})
+}
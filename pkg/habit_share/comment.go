@@ -0,0 +1,126 @@
+package habit_share
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+var CommentNotFoundError = errors.New("Comment could not be found")
+
+const maxCommentLength = 2000
+
+type Comment struct {
+	Id      string
+	HabitId string
+	Author  string
+	Body    string
+	Created time.Time
+	// zero until the comment is edited
+	Edited time.Time
+}
+
+// commentCheck passes for the owner, members and anyone the habit is shared with,
+// returning who the current user is
+func (a *App) commentCheck(habit Habit) (string, error) {
+	if err := a.habitMemberCheck(habit); err != nil {
+		if err := a.habitSharedCheck(habit); err != nil {
+			return "", err
+		}
+	}
+
+	return a.Auth.GetCurrentUser()
+}
+
+func commentBodyCheck(body string) error {
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return &InputError{StringToParse: body}
+	}
+	return nil
+}
+
+// PostComment adds a comment to the habit's thread
+func (a *App) PostComment(habitId string, body string) (string, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return "", err
+	}
+	user, err := a.commentCheck(habit)
+	if err != nil {
+		return "", err
+	}
+	if err := commentBodyCheck(body); err != nil {
+		return "", err
+	}
+
+	return a.Db.CreateComment(Comment{
+		HabitId: habitId,
+		Author:  user,
+		Body:    body,
+		Created: time.Now(),
+	})
+}
+
+// GetComments returns up to limit of the latest comments posted before before,
+// newest first
+func (a *App) GetComments(habitId string, before time.Time, limit int) (comments []Comment, hasMore bool, err error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := a.commentCheck(habit); err != nil {
+		return nil, false, err
+	}
+
+	return a.Db.GetComments(habitId, before, limit)
+}
+
+// EditComment changes the body of a comment the current user posted
+func (a *App) EditComment(habitId string, id string, body string) error {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+	user, err := a.commentCheck(habit)
+	if err != nil {
+		return err
+	}
+	if err := commentBodyCheck(body); err != nil {
+		return err
+	}
+
+	comment, err := a.Db.GetComment(habitId, id)
+	if err != nil {
+		return err
+	}
+	if comment.Author != user {
+		return PermissionDeniedError
+	}
+
+	comment.Body = body
+	comment.Edited = time.Now()
+	return a.Db.SetComment(habitId, comment)
+}
+
+// DeleteComment removes a comment. Authors can delete their own and owners can
+// delete any on their habit.
+func (a *App) DeleteComment(habitId string, id string) error {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+	user, err := a.commentCheck(habit)
+	if err != nil {
+		return err
+	}
+
+	comment, err := a.Db.GetComment(habitId, id)
+	if err != nil {
+		return err
+	}
+	if comment.Author != user && a.habitOwnerCheck(habit) != nil {
+		return PermissionDeniedError
+	}
+
+	return a.Db.DeleteComment(habitId, id)
+}
@@ -0,0 +1,79 @@
+package habit_share_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestComments(t *testing.T) {
+	t.Run("should only let the owner and shared users comment", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+
+		if _, err := friendApp.PostComment(habitId, "hello"); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.PostComment(habitId, "why did you skip Thursday?"); err != nil {
+			t.Fatal("PostComment returned error unexpectedly:", err)
+		}
+		if _, err := app.PostComment(habitId, "I was sick"); err != nil {
+			t.Fatal("PostComment returned error unexpectedly:", err)
+		}
+
+		comments, hasMore, err := app.GetComments(habitId, time.Now().Add(time.Second), 10)
+		if err != nil {
+			t.Fatal("GetComments returned error unexpectedly:", err)
+		}
+		if hasMore || len(comments) != 2 || comments[0].Author != "testUser" || comments[1].Author != "friend" {
+			t.Errorf("expected both comments newest first got %v", comments)
+		}
+	})
+
+	t.Run("should only let authors edit", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		commentId, err := app.PostComment(habitId, "typo")
+		if err != nil {
+			t.Fatal("PostComment returned error unexpectedly:", err)
+		}
+
+		if err := friendApp.EditComment(habitId, commentId, "hijacked"); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+		if err := app.EditComment(habitId, commentId, "fixed"); err != nil {
+			t.Fatal("EditComment returned error unexpectedly:", err)
+		}
+		comment, _ := app.Db.GetComment(habitId, commentId)
+		if comment.Body != "fixed" || comment.Edited.IsZero() {
+			t.Errorf("expected edited comment got %v", comment)
+		}
+		if err := app.EditComment(habitId, commentId, ""); err == nil {
+			t.Error("expected an empty body to be rejected")
+		}
+	})
+
+	t.Run("should let owners delete any comment", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		ownerCommentId, _ := app.PostComment(habitId, "mine")
+		friendCommentId, _ := friendApp.PostComment(habitId, "theirs")
+
+		if err := friendApp.DeleteComment(habitId, ownerCommentId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+		if err := app.DeleteComment(habitId, friendCommentId); err != nil {
+			t.Error("DeleteComment returned error unexpectedly:", err)
+		}
+		if err := app.DeleteComment(habitId, friendCommentId); err != habit_share.CommentNotFoundError {
+			t.Error("expected CommentNotFoundError got:", err)
+		}
+	})
+}
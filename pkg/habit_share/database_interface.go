@@ -3,16 +3,42 @@ package habit_share
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 type Habit struct {
 	Id          string
 	Owner       string
-	SharedWith  map[string]struct{}
+	// Members of a group habit including the Owner, sorted. Each logs their
+	// own activities. Empty for habits with only an Owner.
+	Members []string
+	// how the members' activities are combined, GroupAll or GroupAny
+	GroupMode string
+	// who the habit is shared with and what they can do to it
+	SharedWith  map[string]Permission
+	// when shares in SharedWith stop working, those left out never expire
+	ShareExpiries map[string]time.Time
 	Name        string
 	Description string
-	Frequency   int
-	Archived    bool
+	// Frequency is the number of times the habit should be done each Period
+	Frequency int
+	Period    Period
+	Schedule  Schedule
+	// Frequency, Period and Schedule as they were over time, oldest first.
+	// Empty when they have never been changed.
+	ScheduleHistory []ScheduleChange
+	// Quantitative habits have a Target amount in Unit (e.g. 2 L, 30 pages).
+	// A Target of 0 means the habit is simply done or not.
+	Unit    string
+	Target  float64
+	Minimum float64
+	// Number of days a month that can be EXCUSED, 0 uses DefaultExcusesPerMonth
+	ExcusesPerMonth int
+	// name of the ScoringStrategy, empty is ScoringStreak
+	Scoring  string
+	Archived bool
+	// the Challenge the habit was made for by joining it, empty otherwise
+	ChallengeId string
 }
 
 type Activity struct {
@@ -20,13 +46,56 @@ type Activity struct {
 	HabitId string
 	Logged  Time
 	Status  string
+	// Amount done in the habit's Unit, only used by quantitative habits
+	Amount float64
+	// who logged it, only set for group habits
+	Member string
+}
+
+func (h Habit) IsQuantitative() bool {
+	return h.Target > 0
+}
+
+// ShareExpired is whether the share with user has expired by now
+func (h Habit) ShareExpired(user string, now time.Time) bool {
+	expires, ok := h.ShareExpiries[user]
+	return ok && !now.Before(expires)
+}
+
+func (h Habit) ExcuseLimit() int {
+	if h.ExcusesPerMonth == 0 {
+		return DefaultExcusesPerMonth
+	}
+	return h.ExcusesPerMonth
+}
+
+// ScoringName is the name of the strategy the habit is scored with
+func (h Habit) ScoringName() string {
+	if h.Scoring == "" {
+		return ScoringStreak
+	}
+	return h.Scoring
+}
+
+// StatusForAmount compares amount against the habit's Target and Minimum.
+// A Minimum of 0 means there is no minimum.
+func (h Habit) StatusForAmount(amount float64) string {
+	if amount >= h.Target {
+		return ActivitySuccess
+	}
+	if h.Minimum > 0 && amount >= h.Minimum {
+		return ActivityMinimum
+	}
+	return ActivityNotDone
 }
 
 var ActivityNotFoundError = errors.New("Activity could not be found")
+var ReactionNotFoundError = errors.New("Reaction could not be found")
 var HabitNotFoundError = errors.New("Habit could not be found")
 var UserNotFoundError = errors.New("User could not be found")
 
 var PermissionDeniedError = errors.New("Operation was denied")
+var NotFriendsError = errors.New("Habits can only be shared with friends")
 
 type InputError struct {
 	StringToParse string
@@ -43,8 +112,16 @@ const (
 	ActivitySuccess = "SUCCESS"
 	ActivityMinimum = "MINIMUM"
 	ActivityNotDone = "NOT_DONE"
+	// Sick, travelling etc. Lowers what's required of the period instead of
+	// breaking the streak
+	ActivityExcused = "EXCUSED"
 )
 
+// Enough for a sick week each month without excusing every day
+const DefaultExcusesPerMonth = 4
+
+var ExcuseLimitReachedError = errors.New("No excuses left this month")
+
 /*
 Thoughts on the current API.
 So I made this reflecting on Clean Architecture. I defined, from the habit
@@ -56,15 +133,29 @@ Don't forget habit_share defines the struct. The database providers should know
 Additionally we're replicating the data on either side of this API boundary (not a huge deal given how ephemeral the habit_share side is).
 */
 type HabitsDatabase interface {
+	ChallengesDatabase
+	CommentsDatabase
+	EventsDatabase
+	NotificationsDatabase
+
 	// Not sure this is a good idea. Instead to create a habit struct and the habit id is populated for you and also returned
 	CreateHabit(newHabit Habit) (string, error)
-	ShareHabit(habitId string, friend string) error
+	// sharing with someone the habit is already shared with changes their
+	// permission and expiry, a nil expires never expires
+	ShareHabit(habitId string, friend string, permission Permission, expires *time.Time) error
 	UnShareHabit(habitId string, friend string) error
 	// the value returned should not be modified in case of an in-memory database
 	// avoiding copying
 	GetMyHabits(owner string, limit int, archived bool) ([]Habit, error)
 	// this should not show archived habits
 	GetSharedHabits(owner string, limit int) ([]Habit, error)
+	// every habit of every user that isn't archived, sorted by Id
+	GetUnarchivedHabits() ([]Habit, error)
+	// muting is kept until unmuted or the habit is no longer shared with user
+	MuteHabit(user string, habitId string) error
+	UnmuteHabit(user string, habitId string) error
+	// sorted ids of the habits user muted
+	GetMutedHabits(user string) ([]string, error)
 	// the value returned should not be modified in case of an in-memory database
 	// avoiding copying
 	GetHabit(id string) (Habit, error)
@@ -73,9 +164,94 @@ type HabitsDatabase interface {
 
 	DeleteHabit(id string) error
 
-	CreateActivity(habitId string, logged Time, status string) (string, error)
+	// Id of the activity is populated for you and returned
+	CreateActivity(newActivity Activity) (string, error)
 	GetActivities(habitId string, after Time, before Time, limit int) (activities []Activity, hasMore bool, err error)
 	DeleteActivity(habitId, id string) error
 
-	GetScore(habitId string) (int, error)
+	// replaces the reaction the user already left on the activity
+	AddReaction(habitId string, reaction Reaction) error
+	// sorted by Created
+	GetReactions(habitId string) ([]Reaction, error)
+	DeleteReaction(habitId string, activityId string, user string) error
+
+	// users without settings get the zero value
+	GetUserSettings(user string) (UserSettings, error)
+	SetUserSettings(user string, settings UserSettings) error
+
+	// Id of the vacation is populated for you and returned
+	CreateVacation(newVacation Vacation) (string, error)
+	// sorted by Start
+	GetVacations(owner string) ([]Vacation, error)
+	DeleteVacation(owner string, id string) error
+
+	// Id of the invitation is populated for you and returned
+	CreateInvitation(newInvitation Invitation) (string, error)
+	GetInvitation(id string) (Invitation, error)
+	// sorted by Sent, expired invitations included
+	GetReceivedInvitations(to string) ([]Invitation, error)
+	// sorted by Sent, expired invitations included
+	GetSentInvitations(from string) ([]Invitation, error)
+	DeleteInvitation(id string) error
+
+	CreatePublicLink(newLink PublicLink) error
+	GetPublicLink(token string) (PublicLink, error)
+	// sorted by Created
+	GetPublicLinks(habitId string) ([]PublicLink, error)
+	DeletePublicLink(token string) error
+}
+
+// ChallengesDatabase stores challenges, the habits made by joining them are
+// stored like any other
+type ChallengesDatabase interface {
+	// Id of the challenge is populated for you and returned
+	CreateChallenge(newChallenge Challenge) (string, error)
+	GetChallenge(id string) (Challenge, error)
+	// challenges with participant in their Participants, sorted by Start
+	GetChallenges(participant string) ([]Challenge, error)
+	// challenges that aren't Finished, sorted by End
+	GetUnfinishedChallenges() ([]Challenge, error)
+	SetChallenge(id string, updatedChallenge Challenge) error
+	// LinkChallengeHabit makes habitId participant's habit for the challenge
+	// as long as their linked habit is still previous, empty if they hadn't
+	// joined. Otherwise it fails with an InputError so of two joins at once
+	// only one succeeds.
+	LinkChallengeHabit(id string, participant string, previous string, habitId string) error
+}
+
+// CommentsDatabase stores the comment thread of each habit
+type CommentsDatabase interface {
+	// Id of the comment is populated for you and returned
+	CreateComment(newComment Comment) (string, error)
+	GetComment(habitId string, id string) (Comment, error)
+	// up to limit of the latest comments created before before, newest first.
+	// hasMore is true when there are older ones.
+	GetComments(habitId string, before time.Time, limit int) (comments []Comment, hasMore bool, err error)
+	SetComment(habitId string, updatedComment Comment) error
+	DeleteComment(habitId string, id string) error
+}
+
+// EventsDatabase is the log of events behind each user's feed
+type EventsDatabase interface {
+	// Id of the event is populated for you, higher than any before it, and
+	// returned
+	AppendEvent(newEvent Event) (int64, error)
+	// up to limit of the latest events with user in their Audience and an Id
+	// below before, newest first. A before of 0 starts from the latest.
+	// hasMore is true when there are older ones.
+	GetFeed(user string, before int64, limit int) (events []Event, hasMore bool, err error)
+}
+
+// NotificationsDatabase is each user's inbox of notifications
+type NotificationsDatabase interface {
+	// Id of the notification is populated for you and returned
+	CreateNotification(newNotification Notification) (string, error)
+	GetNotification(user string, id string) (Notification, error)
+	// up to limit of user's latest notifications, newest first. unread leaves
+	// out those already Read.
+	GetNotifications(user string, limit int, unread bool) ([]Notification, error)
+	// user's notifications Created after since, newest first
+	GetNotificationsSince(user string, since time.Time) ([]Notification, error)
+	SetNotification(user string, updatedNotification Notification) error
+	DeleteNotification(user string, id string) error
 }
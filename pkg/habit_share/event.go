@@ -0,0 +1,125 @@
+package habit_share
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	EventActivityLogged = "ACTIVITY_LOGGED"
+	// the habit's streak reached one of StreakMilestones
+	EventMilestone     = "MILESTONE"
+	EventHabitShared   = "HABIT_SHARED"
+	EventHabitArchived = "HABIT_ARCHIVED"
+)
+
+// streaks worth telling friends about
+var StreakMilestones = []int{7, 30, 50, 100, 200, 365, 500, 1000}
+
+// Event is something that happened to a habit, shown in the feeds of those the
+// habit is shared with
+type Event struct {
+	// increases with every event so it doubles as the feed's cursor
+	Id        int64
+	Type      string
+	HabitId   string
+	HabitName string
+	// who did it
+	Actor   string
+	Created time.Time
+	// whose feeds the event is in, left out of feeds
+	Audience []string `json:",omitempty"`
+	// for EventActivityLogged
+	Logged Time   `json:",omitempty"`
+	Status string `json:",omitempty"`
+	// for EventMilestone
+	Score int `json:",omitempty"`
+}
+
+// audience is everyone who can see the habit other than actor
+func (h Habit) audience(actor string) []string {
+	audience := make([]string, 0, len(h.SharedWith)+len(h.Members))
+	for user := range h.SharedWith {
+		if user != actor {
+			audience = append(audience, user)
+		}
+	}
+	for _, member := range h.Members {
+		if member != actor {
+			audience = append(audience, member)
+		}
+	}
+	sort.Strings(audience)
+	return audience
+}
+
+// recordEvent adds event to the feeds of its Audience. Feeds are best effort
+// so a failure is logged rather than failing what was already saved.
+func (a *App) recordEvent(habit Habit, event Event) {
+	if len(event.Audience) == 0 {
+		return
+	}
+
+	event.HabitId = habit.Id
+	event.HabitName = habit.Name
+	event.Created = time.Now()
+	if _, err := a.Db.AppendEvent(event); err != nil {
+		log.Printf("Failed to record %s event of habit %s: %v", event.Type, habit.Id, err)
+	}
+}
+
+func reachedMilestone(previous int, score int) (int, bool) {
+	for _, milestone := range StreakMilestones {
+		if previous < milestone && score >= milestone {
+			return milestone, true
+		}
+	}
+	return 0, false
+}
+
+// GetFeed returns up to limit events on habits shared with the current user,
+// newest first, from before cursor. A cursor of 0 starts from the newest. The
+// next cursor is 0 once there are no more events.
+func (a *App) GetFeed(cursor int64, limit int) (events []Event, nextCursor int64, err error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	feed, hasMore, err := a.Db.GetFeed(user, cursor, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	mutedIds, err := a.Db.GetMutedHabits(user)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// sharing may have ended since the event happened
+	visible := make(map[string]bool)
+	for _, id := range mutedIds {
+		visible[id] = false
+	}
+	events = make([]Event, 0, len(feed))
+	for _, event := range feed {
+		canSee, ok := visible[event.HabitId]
+		if !ok {
+			habit, err := a.Db.GetHabit(event.HabitId)
+			if err != nil && err != HabitNotFoundError {
+				return nil, 0, err
+			}
+			canSee = err == nil && (habit.HasMember(user) || a.habitSharedCheck(habit) == nil)
+			visible[event.HabitId] = canSee
+		}
+		if canSee {
+			event.Audience = nil
+			events = append(events, event)
+		}
+	}
+
+	if hasMore {
+		nextCursor = feed[len(feed)-1].Id
+	}
+	return events, nextCursor, nil
+}
@@ -0,0 +1,130 @@
+package habit_share_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_file"
+)
+
+// failingEvents can't record events
+type failingEvents struct {
+	*habit_share_file.HabitShareFile
+}
+
+func (failingEvents) AppendEvent(habit_share.Event) (int64, error) {
+	return 0, errors.New("failed to append")
+}
+
+func TestFeed(t *testing.T) {
+	t.Run("should show what happened to shared habits newest first", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		today, _ := app.HabitToday(habitId)
+
+		// nobody to tell yet
+		if _, err := app.CreateActivity(habitId, habit_share.Time{Time: today.AddDate(0, 0, -1)}, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if err := app.ArchiveHabit(habitId); err != nil {
+			t.Fatal("ArchiveHabit returned error unexpectedly:", err)
+		}
+
+		events, nextCursor, err := friendApp.GetFeed(0, 10)
+		if err != nil {
+			t.Fatal("GetFeed returned error unexpectedly:", err)
+		}
+		expected := []string{habit_share.EventHabitArchived, habit_share.EventActivityLogged, habit_share.EventHabitShared}
+		if len(events) != len(expected) || nextCursor != 0 {
+			t.Fatalf("expected %v got %v with cursor %d", expected, events, nextCursor)
+		}
+		for i := range expected {
+			if events[i].Type != expected[i] || events[i].HabitId != habitId || events[i].Audience != nil {
+				t.Errorf("expected %s event got %+v", expected[i], events[i])
+			}
+		}
+
+		if events, _, _ := app.GetFeed(0, 10); len(events) != 0 {
+			t.Errorf("expected the owner's feed to be empty got %v", events)
+		}
+	})
+
+	t.Run("should page with the cursor", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		today, _ := app.HabitToday(habitId)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		first, cursor, err := friendApp.GetFeed(0, 1)
+		if err != nil {
+			t.Fatal("GetFeed returned error unexpectedly:", err)
+		}
+		if len(first) != 1 || first[0].Type != habit_share.EventActivityLogged || cursor == 0 {
+			t.Fatalf("expected the activity with a cursor got %v %d", first, cursor)
+		}
+		second, cursor, _ := friendApp.GetFeed(cursor, 1)
+		if len(second) != 1 || second[0].Type != habit_share.EventHabitShared || cursor != 0 {
+			t.Errorf("expected the share and no cursor got %v %d", second, cursor)
+		}
+	})
+
+	t.Run("should announce streak milestones", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		today, _ := app.HabitToday(habitId)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		for days := 6; days >= 0; days-- {
+			logged := habit_share.Time{Time: today.AddDate(0, 0, -days)}
+			if _, err := app.CreateActivity(habitId, logged, habit_share.ActivitySuccess, 0); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+
+		events, _, _ := friendApp.GetFeed(0, 1)
+		if len(events) != 1 || events[0].Type != habit_share.EventMilestone || events[0].Score != 7 {
+			t.Errorf("expected a milestone of 7 got %v", events)
+		}
+	})
+
+	t.Run("should hide events once sharing ends", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if err := app.UnShareHabit(habitId, "friend"); err != nil {
+			t.Fatal("UnShareHabit returned error unexpectedly:", err)
+		}
+
+		if events, _, _ := friendApp.GetFeed(0, 10); len(events) != 0 {
+			t.Errorf("expected no events got %v", events)
+		}
+	})
+
+	t.Run("should still succeed when the event can't be recorded", func(t *testing.T) {
+		db := failingEvents{newTestDb()}
+		app := &habit_share.App{Db: db, Auth: testAuth{}}
+		habitId, _ := app.CreateHabit("mine", 1, habit_share.Period{})
+		today, _ := app.HabitToday(habitId)
+
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Error("ShareHabit returned error unexpectedly:", err)
+		}
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Error("CreateActivity returned error unexpectedly:", err)
+		}
+		if err := app.ArchiveHabit(habitId); err != nil {
+			t.Error("ArchiveHabit returned error unexpectedly:", err)
+		}
+	})
+}
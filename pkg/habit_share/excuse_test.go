@@ -0,0 +1,76 @@
+package habit_share_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestExcuses(t *testing.T) {
+	// a whole month that's already over
+	lastMonth := func(app *habit_share.App, habitId string, day int) habit_share.Time {
+		today, _ := app.HabitToday(habitId)
+		return habit_share.Time{Time: time.Date(today.Year(), today.Month()-1, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	t.Run("should refuse excuses past the monthly limit", func(t *testing.T) {
+		app, _, habitId := newFriendApps(t)
+
+		for day := 1; day <= habit_share.DefaultExcusesPerMonth; day++ {
+			if _, err := app.CreateActivity(habitId, lastMonth(app, habitId, day), habit_share.ActivityExcused, 0); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+		next := lastMonth(app, habitId, habit_share.DefaultExcusesPerMonth+1)
+		if _, err := app.CreateActivity(habitId, next, habit_share.ActivityExcused, 0); err != habit_share.ExcuseLimitReachedError {
+			t.Error("expected ExcuseLimitReachedError got:", err)
+		}
+		// excusing the same day again doesn't use up another
+		if _, err := app.CreateActivity(habitId, lastMonth(app, habitId, 1), habit_share.ActivityExcused, 0); err != nil {
+			t.Error("CreateActivity returned error unexpectedly:", err)
+		}
+		// every month starts afresh
+		today, _ := app.HabitToday(habitId)
+		thisMonth := habit_share.Time{Time: time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)}
+		if _, err := app.CreateActivity(habitId, thisMonth, habit_share.ActivityExcused, 0); err != nil {
+			t.Error("CreateActivity returned error unexpectedly:", err)
+		}
+	})
+
+	t.Run("should use the habit's own limit", func(t *testing.T) {
+		app, _, habitId := newFriendApps(t)
+		if err := app.ChangeExcuseLimit(habitId, 1); err != nil {
+			t.Fatal("ChangeExcuseLimit returned error unexpectedly:", err)
+		}
+
+		if _, err := app.CreateActivity(habitId, lastMonth(app, habitId, 1), habit_share.ActivityExcused, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if _, err := app.CreateActivity(habitId, lastMonth(app, habitId, 2), habit_share.ActivityExcused, 0); err != habit_share.ExcuseLimitReachedError {
+			t.Error("expected ExcuseLimitReachedError got:", err)
+		}
+	})
+
+	t.Run("should count each member's excuses on group habits", func(t *testing.T) {
+		app, friendApp, _ := newFriendApps(t)
+		habitId, err := app.CreateGroupHabit("dinner", 3, habit_share.Period{}, []string{"friend"}, "")
+		if err != nil {
+			t.Fatal("CreateGroupHabit returned error unexpectedly:", err)
+		}
+		if err := app.ChangeExcuseLimit(habitId, 1); err != nil {
+			t.Fatal("ChangeExcuseLimit returned error unexpectedly:", err)
+		}
+
+		if _, err := app.CreateActivity(habitId, lastMonth(app, habitId, 1), habit_share.ActivityExcused, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if _, err := app.CreateActivity(habitId, lastMonth(app, habitId, 2), habit_share.ActivityExcused, 0); err != habit_share.ExcuseLimitReachedError {
+			t.Error("expected ExcuseLimitReachedError got:", err)
+		}
+		// friend still has theirs, even on the day testUser excused
+		if _, err := friendApp.CreateActivity(habitId, lastMonth(app, habitId, 1), habit_share.ActivityExcused, 0); err != nil {
+			t.Error("CreateActivity returned error unexpectedly:", err)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package habit_share
+
+import "math"
+
+type FriendsInterface interface {
+	AreFriends(user string, friend string) (bool, error)
+	// whether user has blocked other
+	IsBlocked(user string, other string) (bool, error)
+}
+
+func (a *App) friendCheck(friend string) error {
+	if a.Friends == nil {
+		return nil
+	}
+
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	friends, err := a.Friends.AreFriends(user, friend)
+	if err != nil {
+		return err
+	}
+	if !friends {
+		return NotFriendsError
+	}
+	// blocking ends the friendship but sharing with someone who blocked you is
+	// refused all the same without letting on about the block
+	if blocked, err := a.blocked(friend, user); err != nil {
+		return err
+	} else if blocked {
+		return NotFriendsError
+	}
+
+	return nil
+}
+
+// blocked is whether owner has blocked user from seeing their habits
+func (a *App) blocked(owner string, user string) (bool, error) {
+	if a.Friends == nil {
+		return false, nil
+	}
+
+	return a.Friends.IsBlocked(owner, user)
+}
+
+// UnShareFriend stops the current user and friend sharing any habits with each
+// other, archived ones included
+func (a *App) UnShareFriend(friend string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if err := a.unShareAll(user, friend); err != nil {
+		return err
+	}
+	return a.unShareAll(friend, user)
+}
+
+// unShareAll unshares every habit owner has with friend
+func (a *App) unShareAll(owner string, friend string) error {
+	habits, err := a.Db.GetMyHabits(owner, math.MaxInt32, true)
+	if err != nil {
+		return err
+	}
+
+	for _, habit := range habits {
+		if _, ok := habit.SharedWith[friend]; !ok {
+			continue
+		}
+		if err := a.Db.UnShareHabit(habit.Id, friend); err != nil {
+			return err
+		}
+		a.publish(habit, LiveHabitChanged, "", friend)
+	}
+
+	return nil
+}
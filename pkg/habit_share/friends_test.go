@@ -0,0 +1,107 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+type testFriends struct {
+	friends map[string]bool
+	// who blocked who
+	blocked map[string]string
+}
+
+func (f testFriends) AreFriends(user string, friend string) (bool, error) {
+	return f.friends[friend], nil
+}
+
+func (f testFriends) IsBlocked(user string, other string) (bool, error) {
+	return f.blocked[user] == other, nil
+}
+
+type otherAuth struct{}
+
+func (otherAuth) GetCurrentUser() (string, error) {
+	return "friend", nil
+}
+
+func TestFriends(t *testing.T) {
+	db := newTestDb()
+	blocked := map[string]string{}
+	app := &habit_share.App{Db: db, Auth: testAuth{}, Friends: testFriends{
+		friends: map[string]bool{"friend": true},
+		blocked: blocked,
+	}}
+	friendApp := &habit_share.App{Db: db, Auth: otherAuth{}, Friends: testFriends{
+		friends: map[string]bool{"testUser": true},
+		blocked: blocked,
+	}}
+
+	habitId, err := app.CreateHabit("mine", 3, habit_share.Period{})
+	if err != nil {
+		t.Fatal("CreateHabit returned error unexpectedly:", err)
+	}
+	friendHabitId, err := friendApp.CreateHabit("theirs", 3, habit_share.Period{})
+	if err != nil {
+		t.Fatal("CreateHabit returned error unexpectedly:", err)
+	}
+
+	t.Run("should refuse to share with non-friends", func(t *testing.T) {
+		if err := app.ShareHabit(habitId, "stranger", habit_share.PermissionView, nil); err != habit_share.NotFriendsError {
+			t.Error("expected NotFriendsError got:", err)
+		}
+	})
+
+	t.Run("should unshare both ways", func(t *testing.T) {
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if err := friendApp.ShareHabit(friendHabitId, "testUser", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if err := app.ArchiveHabit(habitId); err != nil {
+			t.Fatal("ArchiveHabit returned error unexpectedly:", err)
+		}
+
+		if err := app.UnShareFriend("friend"); err != nil {
+			t.Fatal("UnShareFriend returned error unexpectedly:", err)
+		}
+
+		habit, _ := db.GetHabit(habitId)
+		if _, ok := habit.SharedWith["friend"]; ok {
+			t.Error("expected archived habit to be unshared from friend")
+		}
+		shared, _ := app.GetSharedHabits(100)
+		if len(shared) != 0 {
+			t.Error("expected no habits shared with the current user got:", shared)
+		}
+	})
+	t.Run("should hide habits from blocked users", func(t *testing.T) {
+		if err := friendApp.ShareHabit(friendHabitId, "testUser", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		blocked["friend"] = "testUser"
+		defer delete(blocked, "friend")
+
+		shared, err := app.GetSharedHabits(100)
+		if err != nil {
+			t.Fatal("GetSharedHabits returned error unexpectedly:", err)
+		}
+		if len(shared) != 0 {
+			t.Error("expected no habits shared with the current user got:", shared)
+		}
+		if _, err := app.GetHabit(friendHabitId); err == nil {
+			t.Error("expected blocked user to not see the habit")
+		}
+	})
+
+	t.Run("should refuse to share with someone who blocked you", func(t *testing.T) {
+		blocked["friend"] = "testUser"
+		defer delete(blocked, "friend")
+
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != habit_share.NotFriendsError {
+			t.Error("expected NotFriendsError got:", err)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package habit_share
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// the day only counts when every member did it
+	GroupAll = "ALL"
+	// the day counts when any member did it
+	GroupAny = "ANY"
+)
+
+// from least to most done
+var statusRanks = map[string]int{
+	ActivityNotDone: 0,
+	ActivityExcused: 1,
+	ActivityMinimum: 2,
+	ActivitySuccess: 3,
+}
+
+// IsGroup is true for habits co-owned by Members that each log their own
+// activities
+func (h Habit) IsGroup() bool {
+	return len(h.Members) > 0
+}
+
+func (h Habit) HasMember(user string) bool {
+	for _, member := range h.Members {
+		if member == user {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupModeName is how the members' activities are combined, empty is GroupAll
+func (h Habit) GroupModeName() string {
+	if h.GroupMode == "" {
+		return GroupAll
+	}
+	return h.GroupMode
+}
+
+func parseGroupMode(mode string) (string, error) {
+	if mode != "" && mode != GroupAll && mode != GroupAny {
+		return "", &InputError{StringToParse: mode}
+	}
+	return mode, nil
+}
+
+// CombineActivities turns the activities the members of a group habit logged
+// into one a day according to GroupMode so it can be scored like any other
+// habit. Activities of other habits are returned as they are.
+func (h Habit) CombineActivities(activities []Activity) []Activity {
+	if !h.IsGroup() {
+		return activities
+	}
+
+	combined := make([]Activity, 0, len(activities)/len(h.Members)+1)
+	for start := 0; start < len(activities); {
+		end := start
+		members := make(map[string]struct{}, len(h.Members))
+		best, worst := activities[start].Status, activities[start].Status
+		for ; end < len(activities) && activities[end].Logged.Equal(activities[start].Logged.Time); end++ {
+			status := activities[end].Status
+			members[activities[end].Member] = struct{}{}
+			if statusRanks[status] > statusRanks[best] {
+				best = status
+			}
+			if statusRanks[status] < statusRanks[worst] {
+				worst = status
+			}
+		}
+
+		status := best
+		if h.GroupModeName() == GroupAll {
+			status = worst
+			// members that logged nothing haven't done it
+			if len(members) < len(h.Members) {
+				status = ActivityNotDone
+			}
+		}
+		combined = append(combined, Activity{
+			HabitId: h.Id,
+			Logged:  activities[start].Logged,
+			Status:  status,
+		})
+		start = end
+	}
+
+	return combined
+}
+
+// CreateGroupHabit creates a habit the current user co-owns with members
+func (a *App) CreateGroupHabit(name string, frequency int, period Period, members []string, mode string) (string, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+
+	mode, err = parseGroupMode(mode)
+	if err != nil {
+		return "", err
+	}
+	if period != (Period{}) {
+		period, err = NewPeriod(period.Unit, period.Length)
+		if err != nil {
+			return "", err
+		}
+	}
+	if frequency < 1 || frequency > period.MaxFrequency() {
+		return "", &InputError{StringToParse: fmt.Sprint(frequency)}
+	}
+
+	unique := map[string]struct{}{user: {}}
+	for _, member := range members {
+		if _, ok := unique[member]; ok {
+			continue
+		}
+		if err := a.friendCheck(member); err != nil {
+			return "", err
+		}
+		unique[member] = struct{}{}
+	}
+	if len(unique) < 2 {
+		return "", &InputError{StringToParse: fmt.Sprint(members)}
+	}
+	groupMembers := make([]string, 0, len(unique))
+	for member := range unique {
+		groupMembers = append(groupMembers, member)
+	}
+	sort.Strings(groupMembers)
+
+	habit := Habit{
+		Owner:     user,
+		Members:   groupMembers,
+		GroupMode: mode,
+		Name:      name,
+		Frequency: frequency,
+		Period:    period,
+	}
+	return a.createHabit(habit)
+}
+
+func (a *App) ChangeGroupMode(id string, mode string) error {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
+		return err
+	}
+
+	if !habit.IsGroup() {
+		return &InputError{StringToParse: mode}
+	}
+	habit.GroupMode, err = parseGroupMode(mode)
+	if err != nil {
+		return err
+	}
+	err = a.setHabit(id, habit)
+	a.Scores.Invalidate(id)
+	return err
+}
+
+// MemberStatus is how a member of a group habit is doing this period
+type MemberStatus struct {
+	Member    string
+	Successes int
+	Met       bool
+	// what they logged today, empty if nothing
+	Today string
+}
+
+// GetMemberStatuses returns how each member of a group habit is doing in the
+// current period
+func (a *App) GetMemberStatuses(habitId string) ([]MemberStatus, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return nil, err
+	}
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+		return nil, HabitNotFoundError
+	}
+	if !habit.IsGroup() {
+		return nil, nil
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return nil, err
+	}
+	vacations, err := a.Db.GetVacations(habit.Owner)
+	if err != nil {
+		return nil, err
+	}
+	activities, _, err := a.Db.GetActivities(
+		habitId,
+		Time{Time: habit.At(today.Time).Period.Start(today.Time)},
+		Time{Time: today.AddDate(0, 0, 1)},
+		math.MaxInt32,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activitiesOf := make(map[string][]Activity, len(habit.Members))
+	for _, activity := range activities {
+		activitiesOf[activity.Member] = append(activitiesOf[activity.Member], activity)
+	}
+
+	statuses := make([]MemberStatus, len(habit.Members))
+	for i, member := range habit.Members {
+		// each member is judged as if the habit were theirs alone
+		periods := evaluatePeriodsFrom(habit, activitiesOf[member], vacations, today.Time, today.Time)
+		current := periods[len(periods)-1]
+		statuses[i] = MemberStatus{Member: member, Successes: current.Successes, Met: current.Met}
+		for _, activity := range activitiesOf[member] {
+			if activity.Logged.Equal(today.Time) {
+				statuses[i].Today = activity.Status
+			}
+		}
+	}
+
+	return statuses, nil
+}
+
+// memberActivityCheck ensures only the member that logged the activity can
+// remove it
+func (a *App) memberActivityCheck(habit Habit, activityId string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	activity, err := a.findActivity(habit, activityId)
+	if err != nil {
+		return err
+	}
+	if activity.Member != user {
+		return PermissionDeniedError
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestGroupHabits(t *testing.T) {
+	newApps := func(t *testing.T, mode string) (app *habit_share.App, friendApp *habit_share.App, habitId string) {
+		db := newTestDb()
+		app = &habit_share.App{Db: db, Auth: testAuth{}}
+		friendApp = &habit_share.App{Db: db, Auth: otherAuth{}}
+
+		habitId, err := app.CreateGroupHabit("dinner", 3, habit_share.Period{}, []string{"friend"}, mode)
+		if err != nil {
+			t.Fatal("CreateGroupHabit returned error unexpectedly:", err)
+		}
+		return app, friendApp, habitId
+	}
+
+	t.Run("should list the habit for every member", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t, "")
+
+		for _, a := range []*habit_share.App{app, friendApp} {
+			habits, err := a.GetMyHabits(10, false)
+			if err != nil {
+				t.Fatal("GetMyHabits returned error unexpectedly:", err)
+			}
+			if len(habits) != 1 || habits[0].Id != habitId {
+				t.Errorf("expected the group habit got %v", habits)
+			}
+		}
+	})
+
+	t.Run("should need every member in ALL mode", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t, habit_share.GroupAll)
+		today, _ := app.HabitToday(habitId)
+
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if score, _ := app.GetScore(habitId); score != 0 {
+			t.Errorf("expected score 0 with one member done got %d", score)
+		}
+
+		if _, err := friendApp.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if score, _ := app.GetScore(habitId); score != 1 {
+			t.Errorf("expected score 1 with every member done got %d", score)
+		}
+	})
+
+	t.Run("should need one member in ANY mode", func(t *testing.T) {
+		app, _, habitId := newApps(t, habit_share.GroupAny)
+		today, _ := app.HabitToday(habitId)
+
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if score, _ := app.GetScore(habitId); score != 1 {
+			t.Errorf("expected score 1 with one member done got %d", score)
+		}
+	})
+
+	t.Run("should report each member's status", func(t *testing.T) {
+		app, _, habitId := newApps(t, "")
+		today, _ := app.HabitToday(habitId)
+
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		statuses, err := app.GetMemberStatuses(habitId)
+		if err != nil {
+			t.Fatal("GetMemberStatuses returned error unexpectedly:", err)
+		}
+		expected := []habit_share.MemberStatus{
+			{Member: "friend"},
+			{Member: "testUser", Successes: 1, Today: habit_share.ActivitySuccess},
+		}
+		if len(statuses) != len(expected) {
+			t.Fatalf("expected %v got %v", expected, statuses)
+		}
+		for i := range expected {
+			if statuses[i] != expected[i] {
+				t.Errorf("expected %v got %v", expected[i], statuses[i])
+			}
+		}
+	})
+
+	t.Run("should count minimums and excuses towards each member's period", func(t *testing.T) {
+		app, friendApp, _ := newApps(t, "")
+		daily := habit_share.Period{Unit: habit_share.PeriodDay, Length: 1}
+		habitId, err := app.CreateGroupHabit("walk", 1, daily, []string{"friend"}, "")
+		if err != nil {
+			t.Fatal("CreateGroupHabit returned error unexpectedly:", err)
+		}
+		today, _ := app.HabitToday(habitId)
+
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivityMinimum, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.CreateActivity(habitId, today, habit_share.ActivityExcused, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		statuses, err := app.GetMemberStatuses(habitId)
+		if err != nil {
+			t.Fatal("GetMemberStatuses returned error unexpectedly:", err)
+		}
+		expected := []habit_share.MemberStatus{
+			{Member: "friend", Met: true, Today: habit_share.ActivityExcused},
+			{Member: "testUser", Met: true, Today: habit_share.ActivityMinimum},
+		}
+		if len(statuses) != len(expected) {
+			t.Fatalf("expected %v got %v", expected, statuses)
+		}
+		for i := range expected {
+			if statuses[i] != expected[i] {
+				t.Errorf("expected %v got %v", expected[i], statuses[i])
+			}
+		}
+	})
+
+	t.Run("should only let members delete their own activities", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t, "")
+		today, _ := app.HabitToday(habitId)
+
+		activityId, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0)
+		if err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		if err := friendApp.DeleteActivity(habitId, activityId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+		if err := app.DeleteActivity(habitId, activityId); err != nil {
+			t.Error("DeleteActivity returned error unexpectedly:", err)
+		}
+		activities, _, _ := app.GetActivities(habitId, today, habit_share.Time{Time: today.AddDate(0, 0, 1)}, 10)
+		if len(activities) != 1 || activities[0].Member != "friend" {
+			t.Errorf("expected only friend's activity left got %v", activities)
+		}
+	})
+
+	t.Run("should leave managing the habit to the owner", func(t *testing.T) {
+		_, friendApp, habitId := newApps(t, "")
+
+		if _, err := friendApp.GetHabit(habitId); err != nil {
+			t.Error("GetHabit returned error unexpectedly:", err)
+		}
+		if err := friendApp.ArchiveHabit(habitId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError from ArchiveHabit got:", err)
+		}
+		if err := friendApp.DeleteHabit(habitId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError from DeleteHabit got:", err)
+		}
+		if err := friendApp.ShareHabit(habitId, "someone", "", nil); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError from ShareHabit got:", err)
+		}
+		if _, err := friendApp.InviteToHabit(habitId, "someone", ""); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError from InviteToHabit got:", err)
+		}
+		if _, err := friendApp.CreatePublicLink(habitId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError from CreatePublicLink got:", err)
+		}
+	})
+}
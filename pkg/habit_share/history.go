@@ -0,0 +1,69 @@
+package habit_share
+
+import "time"
+
+// ScheduleChange is how often the habit was to be done from a date onwards
+type ScheduleChange struct {
+	// the first entry has the zero Time as it applies since the habit began
+	From      Time
+	Frequency int
+	Period    Period
+	Schedule  Schedule
+}
+
+func (c ScheduleChange) equal(other ScheduleChange) bool {
+	if c.Frequency != other.Frequency || c.Period.normalised() != other.Period.normalised() {
+		return false
+	}
+	if len(c.Schedule.Weekdays) != len(other.Schedule.Weekdays) {
+		return false
+	}
+	for i, weekday := range c.Schedule.Weekdays {
+		if other.Schedule.Weekdays[i] != weekday {
+			return false
+		}
+	}
+	return true
+}
+
+// At returns the habit with the Frequency, Period and Schedule that were in
+// force on day
+func (h Habit) At(day time.Time) Habit {
+	for i := len(h.ScheduleHistory) - 1; i >= 0; i-- {
+		change := h.ScheduleHistory[i]
+		if !change.From.After(day) {
+			h.Frequency = change.Frequency
+			h.Period = change.Period
+			h.Schedule = change.Schedule
+			return h
+		}
+	}
+	return h
+}
+
+// changeSchedule applies the new Frequency, Period and Schedule from day
+// onwards, keeping what came before in ScheduleHistory. Periods are judged by
+// what was in force when they started so a change made part way through a
+// period takes effect from the next one.
+func (h *Habit) changeSchedule(day Time, frequency int, period Period, schedule Schedule) {
+	current := ScheduleChange{Frequency: h.Frequency, Period: h.Period, Schedule: h.Schedule}
+	change := ScheduleChange{From: day, Frequency: frequency, Period: period, Schedule: schedule}
+	h.Frequency = frequency
+	h.Period = period
+	h.Schedule = schedule
+	if current.equal(change) {
+		return
+	}
+
+	// copy as the habit may be shared with an in-memory database
+	history := make([]ScheduleChange, 0, len(h.ScheduleHistory)+2)
+	history = append(history, h.ScheduleHistory...)
+	if len(history) == 0 {
+		history = append(history, current)
+	}
+	// only the last change of the day matters
+	if last := len(history) - 1; history[last].From.Equal(day.Time) {
+		history = history[:last]
+	}
+	h.ScheduleHistory = append(history, change)
+}
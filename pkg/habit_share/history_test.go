@@ -0,0 +1,104 @@
+package habit_share
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleHistory(t *testing.T) {
+	day := func(date string) Time {
+		parsed, _ := time.Parse(DateFormat, date)
+		return Time{Time: parsed}
+	}
+
+	t.Run("should keep what was in force before a change", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+
+		habit.changeSchedule(day("2022-05-23"), 5, Period{}, Schedule{})
+
+		if habit.Frequency != 5 {
+			t.Error("expected frequency of 5 got", habit.Frequency)
+		}
+		if len(habit.ScheduleHistory) != 2 {
+			t.Fatal("expected 2 entries got", habit.ScheduleHistory)
+		}
+		if frequency := habit.At(day("2022-05-22").Time).Frequency; frequency != 3 {
+			t.Error("expected frequency of 3 before the change got", frequency)
+		}
+		if frequency := habit.At(day("2022-05-23").Time).Frequency; frequency != 5 {
+			t.Error("expected frequency of 5 from the change got", frequency)
+		}
+	})
+
+	t.Run("should only keep the last change of the day", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+
+		habit.changeSchedule(day("2022-05-23"), 5, Period{}, Schedule{})
+		habit.changeSchedule(day("2022-05-23"), 4, Period{}, Schedule{})
+
+		if len(habit.ScheduleHistory) != 2 {
+			t.Fatal("expected 2 entries got", habit.ScheduleHistory)
+		}
+		if frequency := habit.At(day("2022-05-24").Time).Frequency; frequency != 4 {
+			t.Error("expected frequency of 4 got", frequency)
+		}
+	})
+
+	t.Run("should not record changes that change nothing", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+
+		habit.changeSchedule(day("2022-05-23"), 3, Period{Unit: PeriodWeek, Length: 1}, Schedule{})
+
+		if len(habit.ScheduleHistory) != 0 {
+			t.Error("expected no history got", habit.ScheduleHistory)
+		}
+	})
+
+	t.Run("should score past weeks with the frequency at the time", func(t *testing.T) {
+		// a Wednesday
+		today := time.Date(2022, time.May, 25, 0, 0, 0, 0, time.UTC)
+		habit := Habit{Frequency: 3}
+		habit.changeSchedule(day("2022-05-23"), 5, Period{}, Schedule{})
+		activities := testActivities([]string{
+			"2022-05-09",
+			"2022-05-10",
+			"2022-05-11",
+			"2022-05-16",
+			"2022-05-17",
+			"2022-05-18",
+		}, ActivitySuccess)
+
+		score := StreakScore{}.Score(habit, activities, nil, today)
+
+		if score != 6 {
+			t.Error("expected streak of 6 got", score)
+		}
+
+		periods := EvaluatePeriods(habit, activities, nil, today)
+		if !periods[1].Met || periods[1].Frequency != 3 {
+			t.Error("expected week of 2022-05-16 to be met with a frequency of 3", periods[1])
+		}
+	})
+
+	t.Run("should start the new period after a change of period", func(t *testing.T) {
+		today := time.Date(2022, time.May, 25, 0, 0, 0, 0, time.UTC)
+		habit := Habit{Frequency: 3}
+		// a Thursday, the week it's in is still judged weekly
+		habit.changeSchedule(day("2022-04-28"), 8, Period{Unit: PeriodMonth, Length: 1}, Schedule{})
+		activities := testActivities([]string{"2022-04-25"}, ActivitySuccess)
+
+		periods := EvaluatePeriods(habit, activities, nil, today)
+
+		if len(periods) != 2 {
+			t.Fatal("expected the week of the change and the rest of May got", periods)
+		}
+		if periods[0].End.Format(DateFormat) != "2022-05-02" {
+			t.Error("expected the week to end 2022-05-02 got", periods[0].End.Format(DateFormat))
+		}
+		if periods[1].Start.Format(DateFormat) != "2022-05-02" ||
+			periods[1].End.Format(DateFormat) != "2022-06-01" ||
+			periods[1].Frequency != 8 {
+			t.Error("expected the rest of May to be judged monthly", periods[1])
+		}
+	})
+}
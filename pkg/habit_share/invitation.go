@@ -0,0 +1,200 @@
+package habit_share
+
+import (
+	"errors"
+	"time"
+)
+
+// Long enough to be seen by someone who doesn't check every day
+const InvitationTtl = 14 * 24 * time.Hour
+
+var InvitationNotFoundError = errors.New("Invitation could not be found")
+
+// Invitation is an offer to share a habit that waits on the recipient to
+// accept it
+type Invitation struct {
+	Id      string
+	HabitId string
+	// the recipient can't see the habit until they accept
+	HabitName string
+	// what the recipient can do once they accept
+	Permission Permission
+	From       string
+	To         string
+	Sent       time.Time
+	Expires    time.Time
+}
+
+func (i Invitation) Expired(now time.Time) bool {
+	return !now.Before(i.Expires)
+}
+
+// InviteToHabit asks to to accept sharing the habit. Inviting them again
+// replaces the pending invitation. An empty permission is PermissionView.
+func (a *App) InviteToHabit(habitId string, to string, permission Permission) (string, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return "", err
+	}
+	if err := a.habitOwnerCheck(habit); err != nil {
+		return "", err
+	}
+	permission, err = ParsePermission(string(permission))
+	if err != nil {
+		return "", err
+	}
+
+	if to == habit.Owner {
+		return "", &InputError{StringToParse: to}
+	}
+	if _, ok := habit.SharedWith[to]; ok {
+		return "", &InputError{StringToParse: to}
+	}
+	if err := a.friendCheck(to); err != nil {
+		return "", err
+	}
+
+	sent, err := a.Db.GetSentInvitations(habit.Owner)
+	if err != nil {
+		return "", err
+	}
+	for _, invitation := range sent {
+		if invitation.HabitId == habitId && invitation.To == to {
+			if err := a.Db.DeleteInvitation(invitation.Id); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	now := time.Now()
+	return a.Db.CreateInvitation(Invitation{
+		HabitId:    habitId,
+		HabitName:  habit.Name,
+		Permission: permission,
+		From:       habit.Owner,
+		To:         to,
+		Sent:       now,
+		Expires:    now.Add(InvitationTtl),
+	})
+}
+
+// pending leaves out and deletes the invitations that have expired
+func (a *App) pending(invitations []Invitation) ([]Invitation, error) {
+	now := time.Now()
+	pending := make([]Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		if !invitation.Expired(now) {
+			pending = append(pending, invitation)
+			continue
+		}
+		err := a.Db.DeleteInvitation(invitation.Id)
+		if err != nil && err != InvitationNotFoundError {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+// GetMyInvitations returns the pending invitations sent to the current user
+func (a *App) GetMyInvitations() ([]Invitation, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := a.Db.GetReceivedInvitations(user)
+	if err != nil {
+		return nil, err
+	}
+	return a.pending(invitations)
+}
+
+// GetSentInvitations returns the pending invitations the current user sent
+func (a *App) GetSentInvitations() ([]Invitation, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := a.Db.GetSentInvitations(user)
+	if err != nil {
+		return nil, err
+	}
+	return a.pending(invitations)
+}
+
+// receivedInvitation returns the invitation if it was sent to the current user
+// and hasn't expired
+func (a *App) receivedInvitation(id string) (Invitation, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	invitation, err := a.Db.GetInvitation(id)
+	if err != nil {
+		return Invitation{}, err
+	}
+	if invitation.To != user || invitation.Expired(time.Now()) {
+		return Invitation{}, InvitationNotFoundError
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation shares the habit with the current user
+func (a *App) AcceptInvitation(id string) error {
+	invitation, err := a.receivedInvitation(id)
+	if err != nil {
+		return err
+	}
+
+	habit, err := a.Db.GetHabit(invitation.HabitId)
+	if err != nil {
+		if err == HabitNotFoundError {
+			// deleted since the invitation was sent
+			a.Db.DeleteInvitation(id)
+			return InvitationNotFoundError
+		}
+		return err
+	}
+	// they may have unfriended or blocked each other since it was sent
+	if err := a.friendCheck(invitation.From); err != nil {
+		return err
+	}
+
+	permission, err := ParsePermission(string(invitation.Permission))
+	if err != nil {
+		return err
+	}
+	if err := a.shareHabit(habit, invitation.To, permission, nil); err != nil {
+		return err
+	}
+	return a.Db.DeleteInvitation(id)
+}
+
+func (a *App) DeclineInvitation(id string) error {
+	invitation, err := a.receivedInvitation(id)
+	if err != nil {
+		return err
+	}
+
+	return a.Db.DeleteInvitation(invitation.Id)
+}
+
+// CancelInvitation takes back an invitation the current user sent
+func (a *App) CancelInvitation(id string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	invitation, err := a.Db.GetInvitation(id)
+	if err != nil {
+		return err
+	}
+	if invitation.From != user {
+		return InvitationNotFoundError
+	}
+
+	return a.Db.DeleteInvitation(id)
+}
@@ -0,0 +1,153 @@
+package habit_share_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestInvitations(t *testing.T) {
+	t.Run("should share once accepted", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+
+		if _, err := app.InviteToHabit(habitId, "friend", ""); err != nil {
+			t.Fatal("InviteToHabit returned error unexpectedly:", err)
+		}
+		// inviting again replaces the first
+		invitationId, err := app.InviteToHabit(habitId, "friend", "")
+		if err != nil {
+			t.Fatal("InviteToHabit returned error unexpectedly:", err)
+		}
+
+		sent, err := app.GetSentInvitations()
+		if err != nil || len(sent) != 1 || sent[0].Id != invitationId {
+			t.Fatal("expected the one invitation to be sent got:", sent, err)
+		}
+		if shared, _ := friendApp.GetSharedHabits(100); len(shared) != 0 {
+			t.Fatal("expected nothing to be shared before accepting got:", shared)
+		}
+		if err := app.AcceptInvitation(invitationId); err != habit_share.InvitationNotFoundError {
+			t.Error("expected only the recipient to accept got:", err)
+		}
+
+		received, err := friendApp.GetMyInvitations()
+		if err != nil || len(received) != 1 || received[0].HabitName != "mine" {
+			t.Fatal("expected the invitation to be received got:", received, err)
+		}
+		if err := friendApp.AcceptInvitation(invitationId); err != nil {
+			t.Fatal("AcceptInvitation returned error unexpectedly:", err)
+		}
+
+		if shared, _ := friendApp.GetSharedHabits(100); len(shared) != 1 {
+			t.Error("expected the habit to be shared got:", shared)
+		}
+		if received, _ := friendApp.GetMyInvitations(); len(received) != 0 {
+			t.Error("expected the invitation to be gone got:", received)
+		}
+	})
+
+	t.Run("should not share once declined", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+
+		invitationId, err := app.InviteToHabit(habitId, "friend", "")
+		if err != nil {
+			t.Fatal("InviteToHabit returned error unexpectedly:", err)
+		}
+		if err := friendApp.DeclineInvitation(invitationId); err != nil {
+			t.Fatal("DeclineInvitation returned error unexpectedly:", err)
+		}
+		if err := friendApp.AcceptInvitation(invitationId); err != habit_share.InvitationNotFoundError {
+			t.Error("expected InvitationNotFoundError got:", err)
+		}
+	})
+
+	t.Run("should drop expired invitations", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+
+		sent := time.Now().Add(-habit_share.InvitationTtl - time.Hour)
+		invitationId, err := app.Db.CreateInvitation(habit_share.Invitation{
+			HabitId: habitId,
+			From:    "testUser",
+			To:      "friend",
+			Sent:    sent,
+			Expires: sent.Add(habit_share.InvitationTtl),
+		})
+		if err != nil {
+			t.Fatal("CreateInvitation returned error unexpectedly:", err)
+		}
+
+		if err := friendApp.AcceptInvitation(invitationId); err != habit_share.InvitationNotFoundError {
+			t.Error("expected InvitationNotFoundError got:", err)
+		}
+		if received, _ := friendApp.GetMyInvitations(); len(received) != 0 {
+			t.Error("expected no invitations got:", received)
+		}
+		if _, err := app.Db.GetInvitation(invitationId); err != habit_share.InvitationNotFoundError {
+			t.Error("expected expired invitation to be deleted got:", err)
+		}
+	})
+
+	t.Run("should only invite friends who haven't been blocked", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		blocked := map[string]string{}
+		app.Friends = testFriends{friends: map[string]bool{"friend": true}, blocked: blocked}
+		friendApp.Friends = testFriends{friends: map[string]bool{"testUser": true}, blocked: blocked}
+
+		if _, err := app.InviteToHabit(habitId, "stranger", ""); err != habit_share.NotFriendsError {
+			t.Error("expected NotFriendsError got:", err)
+		}
+		invitationId, err := app.InviteToHabit(habitId, "friend", "")
+		if err != nil {
+			t.Fatal("InviteToHabit returned error unexpectedly:", err)
+		}
+
+		blocked["testUser"] = "friend"
+		if err := friendApp.AcceptInvitation(invitationId); err != habit_share.NotFriendsError {
+			t.Error("expected NotFriendsError got:", err)
+		}
+		if shared, _ := app.GetSharedWith(habitId); len(shared) != 0 {
+			t.Error("expected the habit not to be shared got:", shared)
+		}
+	})
+
+	t.Run("should not share once they stop being friends", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+		friendsOfFriend := map[string]bool{"testUser": true}
+		app.Friends = testFriends{friends: map[string]bool{"friend": true}}
+		friendApp.Friends = testFriends{friends: friendsOfFriend}
+
+		invitationId, err := app.InviteToHabit(habitId, "friend", "")
+		if err != nil {
+			t.Fatal("InviteToHabit returned error unexpectedly:", err)
+		}
+
+		delete(friendsOfFriend, "testUser")
+		if err := friendApp.AcceptInvitation(invitationId); err != habit_share.NotFriendsError {
+			t.Error("expected NotFriendsError got:", err)
+		}
+		if shared, _ := app.GetSharedWith(habitId); len(shared) != 0 {
+			t.Error("expected the habit not to be shared got:", shared)
+		}
+	})
+
+	t.Run("should notify the recipient like any other share", func(t *testing.T) {
+		app, friendApp, habitId := newFriendApps(t)
+
+		invitationId, err := app.InviteToHabit(habitId, "friend", "")
+		if err != nil {
+			t.Fatal("InviteToHabit returned error unexpectedly:", err)
+		}
+		if err := friendApp.AcceptInvitation(invitationId); err != nil {
+			t.Fatal("AcceptInvitation returned error unexpectedly:", err)
+		}
+
+		notifications, err := friendApp.GetNotifications(10, false)
+		if err != nil {
+			t.Fatal("GetNotifications returned error unexpectedly:", err)
+		}
+		if len(notifications) != 1 || notifications[0].Type != habit_share.NotificationHabitShared || notifications[0].From != "testUser" {
+			t.Error("expected a HABIT_SHARED notification from testUser got:", notifications)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package habit_share
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// sum of each habit's score counting only activities in the window
+	LeaderboardScore = "SCORE"
+	// longest current streak of any one habit within the window, in periods
+	LeaderboardStreak = "STREAK"
+	// average completion rate of the habits over the window
+	LeaderboardCompletion = "COMPLETION"
+)
+
+// days looked back on when no window is given
+const DefaultLeaderboardDays = 30
+const MaxLeaderboardDays = 366
+
+type LeaderboardEntry struct {
+	// users with the same Value share a Rank, 1 is best
+	Rank  int
+	User  string
+	Value float64
+	// how many habits visible to the caller counted towards Value
+	Habits int
+}
+
+// GetLeaderboard ranks the current user and everyone they share habits with,
+// either way, by metric over the last days. Only habits the current user can
+// see count so a friend's private habits never help or hurt them. Group habits
+// count for every member.
+func (a *App) GetLeaderboard(metric string, days int) ([]LeaderboardEntry, error) {
+	if metric == "" {
+		metric = LeaderboardScore
+	}
+	if metric != LeaderboardScore && metric != LeaderboardStreak && metric != LeaderboardCompletion {
+		return nil, &InputError{StringToParse: metric}
+	}
+	if days == 0 {
+		days = DefaultLeaderboardDays
+	}
+	if days < 1 || days > MaxLeaderboardDays {
+		return nil, &InputError{StringToParse: fmt.Sprint(days)}
+	}
+
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	myHabits, err := a.Db.GetMyHabits(user, math.MaxInt32, false)
+	if err != nil {
+		return nil, err
+	}
+	sharedHabits, err := a.Db.GetSharedHabits(user, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	habitsOf := map[string][]Habit{user: nil}
+	credit := func(habit Habit) {
+		if habit.IsGroup() {
+			for _, member := range habit.Members {
+				habitsOf[member] = append(habitsOf[member], habit)
+			}
+			return
+		}
+		habitsOf[habit.Owner] = append(habitsOf[habit.Owner], habit)
+	}
+
+	now := time.Now()
+	seen := make(map[string]struct{})
+	for _, habit := range myHabits {
+		if a.habitMemberCheck(habit) != nil {
+			continue
+		}
+		seen[habit.Id] = struct{}{}
+		credit(habit)
+		// the people I share with are on the board even with nothing to show
+		for friend := range habit.SharedWith {
+			if _, ok := habitsOf[friend]; !ok && !habit.ShareExpired(friend, now) {
+				habitsOf[friend] = nil
+			}
+		}
+	}
+	for _, habit := range sharedHabits {
+		if _, ok := seen[habit.Id]; ok || habit.Archived {
+			continue
+		}
+		if a.habitSharedCheck(habit) != nil {
+			continue
+		}
+		seen[habit.Id] = struct{}{}
+		credit(habit)
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(habitsOf))
+	for member, habits := range habitsOf {
+		value := 0.0
+		for _, habit := range habits {
+			habitValue, err := a.leaderboardValue(habit, metric, days)
+			if err != nil {
+				return nil, err
+			}
+			if metric == LeaderboardStreak {
+				value = math.Max(value, habitValue)
+			} else {
+				value += habitValue
+			}
+		}
+		if metric == LeaderboardCompletion && len(habits) > 0 {
+			value /= float64(len(habits))
+		}
+		entries = append(entries, LeaderboardEntry{User: member, Value: value, Habits: len(habits)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Value != entries[j].Value {
+			return entries[i].Value > entries[j].Value
+		}
+		return entries[i].User < entries[j].User
+	})
+	for i := range entries {
+		if i > 0 && entries[i].Value == entries[i-1].Value {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+
+	return entries, nil
+}
+
+// leaderboardValue measures a single habit by metric over the last days
+func (a *App) leaderboardValue(habit Habit, metric string, days int) (float64, error) {
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return 0, err
+	}
+	since := today.AddDate(0, 0, -days)
+
+	if metric == LeaderboardScore {
+		strategy, err := ScoringFor(habit.Scoring)
+		if err != nil {
+			return 0, err
+		}
+		vacations, err := a.Db.GetVacations(habit.Owner)
+		if err != nil {
+			return 0, err
+		}
+		// only activities in the window count
+		activities, _, err := a.Db.GetActivities(
+			habit.Id,
+			Time{Time: since.AddDate(0, 0, 1)},
+			Time{Time: today.AddDate(0, 0, 1)},
+			math.MaxInt32,
+		)
+		if err != nil {
+			return 0, err
+		}
+		activities = habit.CombineActivities(activities)
+		return float64(strategy.Score(habit, activities, vacations, today.Time)), nil
+	}
+
+	// periods missed before the first activity in the window still count
+	// against the habit
+	periods, fromStart, err := a.evaluateWindow(habit, since, today.Time)
+	if err != nil {
+		return 0, err
+	}
+	if metric == LeaderboardCompletion {
+		return completionRate(periods, since), nil
+	}
+	first := len(periods)
+	for first > 0 && periods[first-1].End.After(since) {
+		first--
+	}
+	current, _ := streaks(periods[first:], fromStart && first == 0)
+	return float64(current), nil
+}
@@ -0,0 +1,117 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestLeaderboard(t *testing.T) {
+	newApps := func(t *testing.T) (app *habit_share.App, friendApp *habit_share.App) {
+		db := newTestDb()
+		app = &habit_share.App{Db: db, Auth: testAuth{}}
+		friendApp = &habit_share.App{Db: db, Auth: otherAuth{}}
+		return app, friendApp
+	}
+	logToday := func(t *testing.T, app *habit_share.App, habitId string) {
+		today, _ := app.HabitToday(habitId)
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+	}
+
+	t.Run("should only count habits the caller can see", func(t *testing.T) {
+		app, friendApp := newApps(t)
+
+		mine, _ := app.CreateHabit("mine", 3, habit_share.Period{})
+		logToday(t, app, mine)
+		if err := app.ShareHabit(mine, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		// friend's private habits don't help them on my board
+		for _, name := range []string{"private", "also private"} {
+			private, _ := friendApp.CreateHabit(name, 3, habit_share.Period{})
+			logToday(t, friendApp, private)
+		}
+
+		entries, err := app.GetLeaderboard(habit_share.LeaderboardScore, 7)
+		if err != nil {
+			t.Fatal("GetLeaderboard returned error unexpectedly:", err)
+		}
+		expected := []habit_share.LeaderboardEntry{
+			{Rank: 1, User: "testUser", Value: 1, Habits: 1},
+			{Rank: 2, User: "friend", Value: 0, Habits: 0},
+		}
+		if len(entries) != len(expected) {
+			t.Fatalf("expected %v got %v", expected, entries)
+		}
+		for i := range expected {
+			if entries[i] != expected[i] {
+				t.Errorf("expected %v got %v", expected[i], entries[i])
+			}
+		}
+
+		// every one of friend's own habits counts on their board
+		entries, _ = friendApp.GetLeaderboard(habit_share.LeaderboardScore, 7)
+		if len(entries) != 2 || entries[0].User != "friend" || entries[0].Value != 2 {
+			t.Errorf("expected friend to lead their own board got %v", entries)
+		}
+	})
+
+	t.Run("should share ranks when tied", func(t *testing.T) {
+		app, friendApp := newApps(t)
+
+		mine, _ := app.CreateHabit("mine", 3, habit_share.Period{})
+		theirs, _ := friendApp.CreateHabit("theirs", 3, habit_share.Period{})
+		logToday(t, app, mine)
+		logToday(t, friendApp, theirs)
+		if err := friendApp.ShareHabit(theirs, "testUser", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+
+		entries, err := app.GetLeaderboard(habit_share.LeaderboardStreak, 30)
+		if err != nil {
+			t.Fatal("GetLeaderboard returned error unexpectedly:", err)
+		}
+		if len(entries) != 2 || entries[0].Rank != 1 || entries[1].Rank != 1 {
+			t.Errorf("expected a tie for first got %v", entries)
+		}
+	})
+
+	t.Run("should only judge the periods in the window", func(t *testing.T) {
+		app, _ := newApps(t)
+
+		daily := habit_share.Period{Unit: habit_share.PeriodDay, Length: 1}
+		habitId, _ := app.CreateHabit("daily", 1, daily)
+		today, _ := app.HabitToday(habitId)
+		for _, days := range []int{40, 1} {
+			if _, err := app.CreateActivity(habitId, habit_share.Time{Time: today.AddDate(0, 0, -days)}, habit_share.ActivitySuccess, 0); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+
+		// yesterday was done but the 6 days before it in the window weren't
+		entries, err := app.GetLeaderboard(habit_share.LeaderboardCompletion, 7)
+		if err != nil {
+			t.Fatal("GetLeaderboard returned error unexpectedly:", err)
+		}
+		if len(entries) != 1 || entries[0].Value != 1.0/7 {
+			t.Errorf("expected a completion rate of 1/7 got %v", entries)
+		}
+		entries, _ = app.GetLeaderboard(habit_share.LeaderboardStreak, 7)
+		if len(entries) != 1 || entries[0].Value != 1 {
+			t.Errorf("expected a streak of 1 got %v", entries)
+		}
+	})
+
+	t.Run("should reject unknown metrics and windows", func(t *testing.T) {
+		app, _ := newApps(t)
+
+		if _, err := app.GetLeaderboard("FASTEST", 7); err == nil {
+			t.Error("expected an unknown metric to fail")
+		}
+		if _, err := app.GetLeaderboard(habit_share.LeaderboardCompletion, habit_share.MaxLeaderboardDays+1); err == nil {
+			t.Error("expected too long a window to fail")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package habit_share
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	// the habit was created or changed, including who it's shared with
+	LiveHabitChanged    = "HABIT_CHANGED"
+	LiveHabitDeleted    = "HABIT_DELETED"
+	LiveActivityCreated = "ACTIVITY_CREATED"
+	LiveActivityDeleted = "ACTIVITY_DELETED"
+)
+
+// LiveInterface pushes changes to users as they happen
+type LiveInterface interface {
+	Publish(users []string, eventType string, data interface{})
+}
+
+// LiveChange is what gets pushed. Clients fetch what changed themselves as
+// what they may see of it depends on who they are.
+type LiveChange struct {
+	HabitId    string
+	ActivityId string `json:",omitempty"`
+}
+
+// viewers is everyone who can currently see the habit along with also, sorted
+func (a *App) viewers(habit Habit, also ...string) []string {
+	unique := map[string]struct{}{habit.Owner: {}}
+	for _, member := range habit.Members {
+		unique[member] = struct{}{}
+	}
+	now := time.Now()
+	for friend := range habit.SharedWith {
+		if habit.ShareExpired(friend, now) {
+			continue
+		}
+		// the same as habitPermissionCheck, when unsure leave them out
+		if blocked, err := a.blocked(habit.Owner, friend); err != nil {
+			log.Printf("Failed to check if %s blocked %s: %v", habit.Owner, friend, err)
+			continue
+		} else if blocked {
+			continue
+		}
+		unique[friend] = struct{}{}
+	}
+	for _, user := range also {
+		unique[user] = struct{}{}
+	}
+
+	viewers := make([]string, 0, len(unique))
+	for user := range unique {
+		viewers = append(viewers, user)
+	}
+	sort.Strings(viewers)
+	return viewers
+}
+
+// publish tells the habit's viewers and also about the change. Pass those who
+// just lost sight of the habit in also so they can drop it.
+func (a *App) publish(habit Habit, eventType string, activityId string, also ...string) {
+	if a.Live == nil {
+		return
+	}
+	a.Live.Publish(a.viewers(habit, also...), eventType, LiveChange{HabitId: habit.Id, ActivityId: activityId})
+}
+
+// createHabit stores the new habit and tells its viewers
+func (a *App) createHabit(habit Habit) (string, error) {
+	id, err := a.Db.CreateHabit(habit)
+	if err != nil {
+		return id, err
+	}
+
+	habit.Id = id
+	a.publish(habit, LiveHabitChanged, "")
+	return id, nil
+}
+
+// setHabit stores the changed habit and tells its viewers
+func (a *App) setHabit(id string, habit Habit) error {
+	if err := a.Db.SetHabit(id, habit); err != nil {
+		return err
+	}
+
+	a.publish(habit, LiveHabitChanged, "")
+	return nil
+}
@@ -0,0 +1,110 @@
+package habit_share_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+type published struct {
+	users     []string
+	eventType string
+	change    habit_share.LiveChange
+}
+
+type recordingLive struct {
+	events *[]published
+}
+
+func (l recordingLive) Publish(users []string, eventType string, data interface{}) {
+	*l.events = append(*l.events, published{users, eventType, data.(habit_share.LiveChange)})
+}
+
+func TestLive(t *testing.T) {
+	newApp := func(t *testing.T) (app *habit_share.App, events *[]published) {
+		db := newTestDb()
+		events = &[]published{}
+		app = &habit_share.App{Db: db, Auth: testAuth{}, Live: recordingLive{events: events}}
+		return app, events
+	}
+
+	t.Run("should push changes to everyone who can see the habit", func(t *testing.T) {
+		app, events := newApp(t)
+
+		habitId, _ := app.CreateHabit("mine", 3, habit_share.Period{})
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		today, _ := app.HabitToday(habitId)
+		activityId, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0)
+		if err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if err := app.ChangeName(habitId, "renamed"); err != nil {
+			t.Fatal("ChangeName returned error unexpectedly:", err)
+		}
+		// friend is told so they can drop the habit
+		if err := app.UnShareHabit(habitId, "friend"); err != nil {
+			t.Fatal("UnShareHabit returned error unexpectedly:", err)
+		}
+		if err := app.DeleteHabit(habitId); err != nil {
+			t.Fatal("DeleteHabit returned error unexpectedly:", err)
+		}
+
+		both := []string{"friend", "testUser"}
+		expected := []published{
+			{[]string{"testUser"}, habit_share.LiveHabitChanged, habit_share.LiveChange{HabitId: habitId}},
+			{both, habit_share.LiveHabitChanged, habit_share.LiveChange{HabitId: habitId}},
+			{both, habit_share.LiveActivityCreated, habit_share.LiveChange{HabitId: habitId, ActivityId: activityId}},
+			{both, habit_share.LiveHabitChanged, habit_share.LiveChange{HabitId: habitId}},
+			{both, habit_share.LiveHabitChanged, habit_share.LiveChange{HabitId: habitId}},
+			{[]string{"testUser"}, habit_share.LiveHabitDeleted, habit_share.LiveChange{HabitId: habitId}},
+		}
+		if !reflect.DeepEqual(*events, expected) {
+			t.Errorf("expected %+v got %+v", expected, *events)
+		}
+	})
+
+	t.Run("should not push failed changes", func(t *testing.T) {
+		app, events := newApp(t)
+
+		if err := app.DeleteActivity("missing", "missing"); err == nil {
+			t.Error("expected deleting from a missing habit to fail")
+		}
+		if len(*events) != 0 {
+			t.Errorf("expected nothing pushed got %+v", *events)
+		}
+	})
+
+	t.Run("should not push changes to users the owner blocked", func(t *testing.T) {
+		app, events := newApp(t)
+		blocked := map[string]string{}
+		app.Friends = testFriends{friends: map[string]bool{"friend": true}, blocked: blocked}
+
+		habitId, _ := app.CreateHabit("mine", 3, habit_share.Period{})
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		blocked["testUser"] = "friend"
+		*events = nil
+
+		today, _ := app.HabitToday(habitId)
+		activityId, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0)
+		if err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if err := app.ChangeName(habitId, "renamed"); err != nil {
+			t.Fatal("ChangeName returned error unexpectedly:", err)
+		}
+
+		owner := []string{"testUser"}
+		expected := []published{
+			{owner, habit_share.LiveActivityCreated, habit_share.LiveChange{HabitId: habitId, ActivityId: activityId}},
+			{owner, habit_share.LiveHabitChanged, habit_share.LiveChange{HabitId: habitId}},
+		}
+		if !reflect.DeepEqual(*events, expected) {
+			t.Errorf("expected %+v got %+v", expected, *events)
+		}
+	})
+}
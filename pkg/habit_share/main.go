@@ -2,11 +2,21 @@ package habit_share
 
 import (
 	"fmt"
+	"log"
+	"time"
 )
 
 type App struct {
 	Db   HabitsDatabase
 	Auth AuthInterface
+	// optional, without it every score is calculated from scratch
+	Scores *ScoreCache
+	// optional, without it everyone is considered a friend
+	Friends FriendsInterface
+	// optional, delivery channels besides the inbox
+	Notifiers []Notifier
+	// optional, without it changes aren't pushed to anyone
+	Live LiveInterface
 }
 
 func (a *App) habitOwnerCheck(habit Habit) error {
@@ -32,6 +42,20 @@ func (a *App) habitOwnerCheck(habit Habit) error {
 	return nil
 }
 
+// habitMemberCheck passes for the owner and the members of a group habit
+func (a *App) habitMemberCheck(habit Habit) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if habit.Owner != user && !habit.HasMember(user) {
+		return PermissionDeniedError
+	}
+
+	return nil
+}
+
 func (a *App) habitIdOwnerCheck(habitId string) error {
 	habit, err := a.Db.GetHabit(habitId)
 	if err != nil {
@@ -51,7 +75,7 @@ func (a *App) habitSharedCheck(habit Habit) error {
 		return PermissionDeniedError
 	}
 
-	return nil
+	return a.habitPermissionCheck(habit, PermissionView)
 }
 
 // ArchiveHabit implements HabitsDatabase
@@ -65,7 +89,16 @@ func (a *App) ArchiveHabit(id string) error {
 	}
 
 	habit.Archived = true
-	return a.Db.SetHabit(id, habit)
+	if err := a.setHabit(id, habit); err != nil {
+		return err
+	}
+
+	a.recordEvent(habit, Event{
+		Type:     EventHabitArchived,
+		Actor:    habit.Owner,
+		Audience: habit.audience(habit.Owner),
+	})
+	return nil
 }
 
 // ChangeFrequency implements HabitsDatabase
@@ -75,63 +108,345 @@ func (a *App) ChangeFrequency(id string, newFrequency int) error {
 	if err != nil {
 		return err
 	}
-	if err := a.habitOwnerCheck(habit); err != nil {
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
+		return err
+	}
+
+	if newFrequency < 1 || newFrequency > habit.Period.MaxFrequency() {
+		return &InputError{StringToParse: fmt.Sprint(newFrequency)}
+	}
+	// the frequency of a scheduled habit is implied by its weekdays
+	if !habit.Schedule.IsWeekly() && newFrequency != len(habit.Schedule.Weekdays) {
+		return &InputError{StringToParse: fmt.Sprint(newFrequency)}
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return err
+	}
+	habit.changeSchedule(today, newFrequency, habit.Period, habit.Schedule)
+	err = a.setHabit(id, habit)
+	a.Scores.Invalidate(id)
+	return err
+}
+
+// ChangePeriod changes the window the frequency is counted over. As the old
+// frequency rarely makes sense in a new period it is changed at the same time.
+// A newFrequency of 0 keeps the current frequency.
+func (a *App) ChangePeriod(id string, newPeriod Period, newFrequency int) error {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
 		return err
 	}
 
-	if newFrequency < 1 || newFrequency > 7 {
+	period, err := NewPeriod(newPeriod.Unit, newPeriod.Length)
+	if err != nil {
+		return err
+	}
+	// weekdays repeat every week so other periods don't make sense
+	if !habit.Schedule.IsWeekly() && !period.IsWeekly() {
+		return &InputError{StringToParse: fmt.Sprint(period)}
+	}
+	if newFrequency == 0 {
+		newFrequency = habit.Frequency
+	}
+	if newFrequency < 1 || newFrequency > period.MaxFrequency() {
 		return &InputError{StringToParse: fmt.Sprint(newFrequency)}
 	}
-	habit.Frequency = newFrequency
-	return a.Db.SetHabit(id, habit)
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return err
+	}
+	habit.changeSchedule(today, newFrequency, period, habit.Schedule)
+	err = a.setHabit(id, habit)
+	a.Scores.Invalidate(id)
+	return err
+}
+
+// ChangeExcuseLimit changes how many days a month can be EXCUSED
+func (a *App) ChangeExcuseLimit(id string, excusesPerMonth int) error {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
+		return err
+	}
+
+	if excusesPerMonth < 1 || excusesPerMonth > 31 {
+		return &InputError{StringToParse: fmt.Sprint(excusesPerMonth)}
+	}
+	habit.ExcusesPerMonth = excusesPerMonth
+	return a.setHabit(id, habit)
+}
+
+// ChangeTarget makes the habit quantitative. Activities already logged keep
+// the status they were given. A target of 0 makes the habit done or not again.
+func (a *App) ChangeTarget(id string, unit string, target float64, minimum float64) error {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
+		return err
+	}
+
+	if target < 0 {
+		return &InputError{StringToParse: fmt.Sprint(target)}
+	}
+	if minimum < 0 || minimum > target {
+		return &InputError{StringToParse: fmt.Sprint(minimum)}
+	}
+	if target == 0 {
+		unit = ""
+	}
+
+	habit.Unit = unit
+	habit.Target = target
+	habit.Minimum = minimum
+	return a.setHabit(id, habit)
+}
+
+// ChangeSchedule ties the habit to specific days of the week. An empty list of
+// weekdays returns the habit to "Frequency times per week".
+func (a *App) ChangeSchedule(id string, weekdays []time.Weekday) error {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
+		return err
+	}
+
+	schedule, err := NewSchedule(weekdays)
+	if err != nil {
+		return err
+	}
+	if !schedule.IsWeekly() && !habit.Period.IsWeekly() {
+		return &InputError{StringToParse: fmt.Sprint(habit.Period)}
+	}
+	frequency := habit.Frequency
+	if !schedule.IsWeekly() {
+		frequency = len(schedule.Weekdays)
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return err
+	}
+	habit.changeSchedule(today, frequency, habit.Period, schedule)
+	err = a.setHabit(id, habit)
+	a.Scores.Invalidate(id)
+	return err
+}
+
+// excuseCheck ensures the month logged falls in still has excuses left.
+// Excusing a day that is already excused doesn't use up another excuse.
+// For group habits only the member's own excuses count.
+func (a *App) excuseCheck(habit Habit, logged Time, member string) error {
+	monthStart := time.Date(logged.Year(), logged.Month(), 1, 0, 0, 0, 0, time.UTC)
+	activities, _, err := a.Db.GetActivities(
+		habit.Id,
+		Time{Time: monthStart},
+		Time{Time: monthStart.AddDate(0, 1, 0)},
+		32,
+	)
+	if err != nil {
+		return err
+	}
+
+	loggedDay := logged.Format(DateFormat)
+	excused := 0
+	for _, activity := range activities {
+		if activity.Status != ActivityExcused || activity.Member != member {
+			continue
+		}
+		if activity.Logged.Format(DateFormat) == loggedDay {
+			return nil
+		}
+		excused++
+	}
+
+	if excused >= habit.ExcuseLimit() {
+		return ExcuseLimitReachedError
+	}
+	return nil
 }
 
 // CreateActivity implements HabitsDatabase
-func (a *App) CreateActivity(habitId string, logged Time, status string) (string, error) {
-	if err := a.habitIdOwnerCheck(habitId); err != nil {
+// The status of quantitative habits is derived from amount and the habit's
+// thresholds so the given status is ignored unless it is EXCUSED.
+func (a *App) CreateActivity(habitId string, logged Time, status string, amount float64) (string, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return "", err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionLog); err != nil {
+		return "", err
+	}
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
 		return "", err
 	}
+	member := ""
+	if habit.IsGroup() {
+		// members log their own activities, sharing only lets others watch
+		if !habit.HasMember(user) {
+			return "", PermissionDeniedError
+		}
+		member = user
+	}
+
+	if status == ActivityExcused {
+		if err := a.excuseCheck(habit, logged, member); err != nil {
+			return "", err
+		}
+		amount = 0
+	} else if habit.IsQuantitative() {
+		if amount < 0 {
+			return "", &InputError{StringToParse: fmt.Sprint(amount)}
+		}
+		status = habit.StatusForAmount(amount)
+	} else if amount != 0 {
+		return "", &InputError{StringToParse: fmt.Sprint(amount)}
+	}
 
 	if status != ActivitySuccess &&
 		status != ActivityMinimum &&
-		status != ActivityNotDone {
+		status != ActivityNotDone &&
+		status != ActivityExcused {
 		return "", &InputError{StringToParse: status}
 	}
 
-	return a.Db.CreateActivity(habitId, logged, status)
+	audience := habit.audience(user)
+	// only worth scoring twice when someone will hear about a milestone
+	previousScore := 0
+	if len(audience) > 0 && habit.ScoringName() == ScoringStreak {
+		previousScore, err = a.score(habit)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	update := a.beforeScoreChange(habit, logged.Time)
+	id, err := a.Db.CreateActivity(Activity{
+		HabitId: habitId,
+		Logged:  logged,
+		Status:  status,
+		Amount:  amount,
+		Member:  member,
+	})
+	if err != nil {
+		update.ok = false
+	}
+	a.afterScoreChange(update, status)
+	if err != nil {
+		return id, err
+	}
+	a.publish(habit, LiveActivityCreated, id)
+
+	a.recordEvent(habit, Event{
+		Type:     EventActivityLogged,
+		Actor:    user,
+		Audience: audience,
+		Logged:   logged,
+		Status:   status,
+	})
+	if len(audience) > 0 && habit.ScoringName() == ScoringStreak {
+		score, err := a.score(habit)
+		if err != nil {
+			log.Printf("Failed to score habit %s for milestones: %v", habitId, err)
+		} else if milestone, ok := reachedMilestone(previousScore, score); ok {
+			a.recordEvent(habit, Event{
+				Type:     EventMilestone,
+				Actor:    user,
+				Audience: audience,
+				Score:    milestone,
+			})
+		}
+	}
+	return id, nil
 }
 
 // CreateHabit implements HabitsDatabase
-func (a *App) CreateHabit(name string, frequency int) (string, error) {
+// The zero Period is a week.
+func (a *App) CreateHabit(name string, frequency int, period Period) (string, error) {
 	user, err := a.Auth.GetCurrentUser()
 	if err != nil {
 		return "", err
 	}
 
-	if frequency < 1 || frequency > 7 {
+	if period != (Period{}) {
+		period, err = NewPeriod(period.Unit, period.Length)
+		if err != nil {
+			return "", err
+		}
+	}
+	if frequency < 1 || frequency > period.MaxFrequency() {
 		return "", &InputError{StringToParse: fmt.Sprint(frequency)}
 	}
 
-	habit := Habit{Owner: user, Name: name, Frequency: frequency}
-	return a.Db.CreateHabit(habit)
+	habit := Habit{Owner: user, Name: name, Frequency: frequency, Period: period}
+	return a.createHabit(habit)
 }
 
 // DeleteActivity implements HabitsDatabase
 func (a *App) DeleteActivity(habitId string, id string) error {
-	if err := a.habitIdOwnerCheck(habitId); err != nil {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
 		return err
 	}
+	if err := a.habitPermissionCheck(habit, PermissionLog); err != nil {
+		return err
+	}
+	if habit.IsGroup() {
+		if err := a.memberActivityCheck(habit, id); err != nil {
+			return err
+		}
+	}
 
-	return a.Db.DeleteActivity(habitId, id)
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return err
+	}
+	update := a.beforeScoreChange(habit, today.Time)
+	// the day of any other activity isn't known so its score is dropped
+	if update.previousId != id {
+		update.ok = false
+	}
+	err = a.Db.DeleteActivity(habitId, id)
+	if err != nil {
+		update.ok = false
+	}
+	a.afterScoreChange(update, "")
+	if err != nil {
+		return err
+	}
+	a.publish(habit, LiveActivityDeleted, id)
+	return nil
 }
 
 // DeleteHabit implements HabitsDatabase
 func (a *App) DeleteHabit(id string) error {
-	if err := a.habitIdOwnerCheck(id); err != nil {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitOwnerCheck(habit); err != nil {
 		return err
 	}
 
-	return a.Db.DeleteHabit(id)
+	err = a.Db.DeleteHabit(id)
+	a.Scores.Invalidate(id)
+	if err != nil {
+		return err
+	}
+	a.publish(habit, LiveHabitDeleted, "")
+	return nil
 }
 
 // GetActivities implements HabitsDatabase
@@ -145,7 +460,7 @@ func (a *App) GetActivities(
 	if err != nil {
 		return nil, false, err
 	}
-	if a.habitOwnerCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
 		return nil, false, HabitNotFoundError
 	}
 
@@ -158,7 +473,7 @@ func (a *App) GetHabit(id string) (Habit, error) {
 	if err != nil {
 		return Habit{}, err
 	}
-	if a.habitOwnerCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
 		return Habit{}, HabitNotFoundError
 	}
 
@@ -175,41 +490,19 @@ func (a *App) GetMyHabits(limit int, archived bool) ([]Habit, error) {
 	return a.Db.GetMyHabits(user, limit, archived)
 }
 
-// GetScore implements HabitsDatabase
-func (a *App) GetScore(habitId string) (int, error) {
-	habit, err := a.Db.GetHabit(habitId)
-	if err != nil {
-		return 0, err
-	}
-
-	if err := a.habitOwnerCheck(habit); err != nil {
-		if err := a.habitSharedCheck(habit); err != nil {
-			// neither owned nor shared
-			return 0, err
-		}
-		// not owner but shared
-	}
-
-	return a.Db.GetScore(habitId)
-}
-
 // GetSharedHabits implements HabitsDatabase
+// Muted habits are left out.
 func (a *App) GetSharedHabits(limit int) ([]Habit, error) {
-	user, err := a.Auth.GetCurrentUser()
-	if err != nil {
-		return nil, err
-	}
-
-	return a.Db.GetSharedHabits(user, limit)
+	return a.sharedHabits(limit, false)
 }
 
 // GetSharedWith implements HabitsDatabase
-func (a *App) GetSharedWith(habitId string) (map[string]struct{}, error) {
+func (a *App) GetSharedWith(habitId string) (map[string]Permission, error) {
 	habit, err := a.Db.GetHabit(habitId)
 	if err != nil {
 		return nil, err
 	}
-	if a.habitOwnerCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
 		return nil, HabitNotFoundError
 	}
 
@@ -223,13 +516,13 @@ func (a *App) ChangeName(id string, newName string) error {
 		return err
 	}
 
-	if err := a.habitOwnerCheck(habit); err != nil {
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
 		return err
 	}
 
 	// TODO disallow characters like \n for readability
 	habit.Name = newName;
-	return a.Db.SetHabit(id, habit)
+	return a.setHabit(id, habit)
 }
 
 // ChangeDescription
@@ -239,30 +532,84 @@ func (a *App) ChangeDescription(id string, newDescription string) error {
 		return err
 	}
 
-	if err := a.habitOwnerCheck(habit); err != nil {
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
 		return err
 	}
 
 	habit.Description = newDescription
-	return a.Db.SetHabit(id, habit)
+	return a.setHabit(id, habit)
 }
 
 // ShareHabit implements HabitsDatabase
-func (a *App) ShareHabit(habitId string, friend string) error {
-	if err := a.habitIdOwnerCheck(habitId); err != nil {
+// An empty permission is PermissionView. A nil expires shares until unshared.
+func (a *App) ShareHabit(habitId string, friend string, permission Permission, expires *time.Time) error {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+	if err := a.habitOwnerCheck(habit); err != nil {
+		return err
+	}
+	permission, err = ParsePermission(string(permission))
+	if err != nil {
+		return err
+	}
+	if expires != nil && !expires.After(time.Now()) {
+		return &InputError{StringToParse: expires.String()}
+	}
+	if err := a.friendCheck(friend); err != nil {
 		return err
 	}
 
-	return a.Db.ShareHabit(habitId, friend)
+	return a.shareHabit(habit, friend, permission, expires)
+}
+
+// shareHabit shares habit with friend and lets them know. The caller checks
+// they're allowed to.
+func (a *App) shareHabit(habit Habit, friend string, permission Permission, expires *time.Time) error {
+	// changing what a friend can do isn't news to them
+	_, alreadyShared := habit.SharedWith[friend]
+	if err := a.Db.ShareHabit(habit.Id, friend, permission, expires); err != nil {
+		return err
+	}
+	a.publish(habit, LiveHabitChanged, "", friend)
+	if alreadyShared {
+		return nil
+	}
+	// the share is saved so telling the friend is best effort
+	if err := a.notify(habit, Notification{User: friend, Type: NotificationHabitShared, From: habit.Owner}); err != nil {
+		log.Printf("Failed to notify %s of habit %s being shared: %v", friend, habit.Id, err)
+	}
+	a.recordEvent(habit, Event{
+		Type:     EventHabitShared,
+		Actor:    habit.Owner,
+		Audience: []string{friend},
+	})
+	return nil
 }
 
 // UnShareHabit implements HabitsDatabase
 func (a *App) UnShareHabit(habitId string, friend string) error {
-	if err := a.habitIdOwnerCheck(habitId); err != nil {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+	if err := a.habitOwnerCheck(habit); err != nil {
 		return err
 	}
 
-	return a.Db.UnShareHabit(habitId, friend)
+	_, wasShared := habit.SharedWith[friend]
+	if err := a.Db.UnShareHabit(habitId, friend); err != nil {
+		return err
+	}
+	a.publish(habit, LiveHabitChanged, "", friend)
+	if !wasShared {
+		return nil
+	}
+	if err := a.notify(habit, Notification{User: friend, Type: NotificationHabitUnshared, From: habit.Owner}); err != nil {
+		log.Printf("Failed to notify %s of habit %s being unshared: %v", friend, habitId, err)
+	}
+	return nil
 }
 
 // UnarchiveHabit implements HabitsDatabase
@@ -277,5 +624,5 @@ func (a *App) UnarchiveHabit(id string) error {
 	}
 
 	habit.Archived = false;
-	return a.Db.SetHabit(id, habit)
+	return a.setHabit(id, habit)
 }
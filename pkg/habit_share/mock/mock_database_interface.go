@@ -6,6 +6,7 @@ package mock_habit_share
 
 import (
 	reflect "reflect"
+	time "time"
 
 	habit_share "github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 	gomock "github.com/golang/mock/gomock"
@@ -34,19 +35,78 @@ func (m *MockHabitsDatabase) EXPECT() *MockHabitsDatabaseMockRecorder {
 	return m.recorder
 }
 
+// AddReaction mocks base method.
+func (m *MockHabitsDatabase) AddReaction(habitId string, reaction habit_share.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", habitId, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockHabitsDatabaseMockRecorder) AddReaction(habitId, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockHabitsDatabase)(nil).AddReaction), habitId, reaction)
+}
+
+// AppendEvent mocks base method.
+func (m *MockHabitsDatabase) AppendEvent(newEvent habit_share.Event) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvent", newEvent)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendEvent indicates an expected call of AppendEvent.
+func (mr *MockHabitsDatabaseMockRecorder) AppendEvent(newEvent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvent", reflect.TypeOf((*MockHabitsDatabase)(nil).AppendEvent), newEvent)
+}
+
 // CreateActivity mocks base method.
-func (m *MockHabitsDatabase) CreateActivity(habitId string, logged habit_share.Time, status string) (string, error) {
+func (m *MockHabitsDatabase) CreateActivity(newActivity habit_share.Activity) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateActivity", habitId, logged, status)
+	ret := m.ctrl.Call(m, "CreateActivity", newActivity)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateActivity indicates an expected call of CreateActivity.
-func (mr *MockHabitsDatabaseMockRecorder) CreateActivity(habitId, logged, status interface{}) *gomock.Call {
+func (mr *MockHabitsDatabaseMockRecorder) CreateActivity(newActivity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateActivity), newActivity)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockHabitsDatabase) CreateChallenge(newChallenge habit_share.Challenge) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", newChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockHabitsDatabaseMockRecorder) CreateChallenge(newChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateChallenge), newChallenge)
+}
+
+// CreateComment mocks base method.
+func (m *MockHabitsDatabase) CreateComment(newComment habit_share.Comment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", newComment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockHabitsDatabaseMockRecorder) CreateComment(newComment interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateActivity), habitId, logged, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateComment), newComment)
 }
 
 // CreateHabit mocks base method.
@@ -64,6 +124,65 @@ func (mr *MockHabitsDatabaseMockRecorder) CreateHabit(newHabit interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateHabit), newHabit)
 }
 
+// CreateInvitation mocks base method.
+func (m *MockHabitsDatabase) CreateInvitation(newInvitation habit_share.Invitation) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", newInvitation)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockHabitsDatabaseMockRecorder) CreateInvitation(newInvitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateInvitation), newInvitation)
+}
+
+// CreateNotification mocks base method.
+func (m *MockHabitsDatabase) CreateNotification(newNotification habit_share.Notification) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", newNotification)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockHabitsDatabaseMockRecorder) CreateNotification(newNotification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateNotification), newNotification)
+}
+
+// CreatePublicLink mocks base method.
+func (m *MockHabitsDatabase) CreatePublicLink(newLink habit_share.PublicLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePublicLink", newLink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePublicLink indicates an expected call of CreatePublicLink.
+func (mr *MockHabitsDatabaseMockRecorder) CreatePublicLink(newLink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePublicLink", reflect.TypeOf((*MockHabitsDatabase)(nil).CreatePublicLink), newLink)
+}
+
+// CreateVacation mocks base method.
+func (m *MockHabitsDatabase) CreateVacation(newVacation habit_share.Vacation) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVacation", newVacation)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVacation indicates an expected call of CreateVacation.
+func (mr *MockHabitsDatabaseMockRecorder) CreateVacation(newVacation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVacation", reflect.TypeOf((*MockHabitsDatabase)(nil).CreateVacation), newVacation)
+}
+
 // DeleteActivity mocks base method.
 func (m *MockHabitsDatabase) DeleteActivity(habitId, id string) error {
 	m.ctrl.T.Helper()
@@ -78,6 +197,20 @@ func (mr *MockHabitsDatabaseMockRecorder) DeleteActivity(habitId, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActivity", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteActivity), habitId, id)
 }
 
+// DeleteComment mocks base method.
+func (m *MockHabitsDatabase) DeleteComment(habitId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", habitId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockHabitsDatabaseMockRecorder) DeleteComment(habitId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteComment), habitId, id)
+}
+
 // DeleteHabit mocks base method.
 func (m *MockHabitsDatabase) DeleteHabit(id string) error {
 	m.ctrl.T.Helper()
@@ -92,6 +225,76 @@ func (mr *MockHabitsDatabaseMockRecorder) DeleteHabit(id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteHabit), id)
 }
 
+// DeleteInvitation mocks base method.
+func (m *MockHabitsDatabase) DeleteInvitation(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockHabitsDatabaseMockRecorder) DeleteInvitation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteInvitation), id)
+}
+
+// DeleteNotification mocks base method.
+func (m *MockHabitsDatabase) DeleteNotification(user, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotification", user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotification indicates an expected call of DeleteNotification.
+func (mr *MockHabitsDatabaseMockRecorder) DeleteNotification(user, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteNotification), user, id)
+}
+
+// DeletePublicLink mocks base method.
+func (m *MockHabitsDatabase) DeletePublicLink(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublicLink", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePublicLink indicates an expected call of DeletePublicLink.
+func (mr *MockHabitsDatabaseMockRecorder) DeletePublicLink(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublicLink", reflect.TypeOf((*MockHabitsDatabase)(nil).DeletePublicLink), token)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockHabitsDatabase) DeleteReaction(habitId, activityId, user string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", habitId, activityId, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockHabitsDatabaseMockRecorder) DeleteReaction(habitId, activityId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteReaction), habitId, activityId, user)
+}
+
+// DeleteVacation mocks base method.
+func (m *MockHabitsDatabase) DeleteVacation(owner, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVacation", owner, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVacation indicates an expected call of DeleteVacation.
+func (mr *MockHabitsDatabaseMockRecorder) DeleteVacation(owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVacation", reflect.TypeOf((*MockHabitsDatabase)(nil).DeleteVacation), owner, id)
+}
+
 // GetActivities mocks base method.
 func (m *MockHabitsDatabase) GetActivities(habitId string, after, before habit_share.Time, limit int) ([]habit_share.Activity, bool, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +311,83 @@ func (mr *MockHabitsDatabaseMockRecorder) GetActivities(habitId, after, before,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivities", reflect.TypeOf((*MockHabitsDatabase)(nil).GetActivities), habitId, after, before, limit)
 }
 
+// GetChallenge mocks base method.
+func (m *MockHabitsDatabase) GetChallenge(id string) (habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", id)
+	ret0, _ := ret[0].(habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockHabitsDatabaseMockRecorder) GetChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockHabitsDatabase)(nil).GetChallenge), id)
+}
+
+// GetChallenges mocks base method.
+func (m *MockHabitsDatabase) GetChallenges(participant string) ([]habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenges", participant)
+	ret0, _ := ret[0].([]habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenges indicates an expected call of GetChallenges.
+func (mr *MockHabitsDatabaseMockRecorder) GetChallenges(participant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenges", reflect.TypeOf((*MockHabitsDatabase)(nil).GetChallenges), participant)
+}
+
+// GetComment mocks base method.
+func (m *MockHabitsDatabase) GetComment(habitId, id string) (habit_share.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", habitId, id)
+	ret0, _ := ret[0].(habit_share.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockHabitsDatabaseMockRecorder) GetComment(habitId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockHabitsDatabase)(nil).GetComment), habitId, id)
+}
+
+// GetComments mocks base method.
+func (m *MockHabitsDatabase) GetComments(habitId string, before time.Time, limit int) ([]habit_share.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", habitId, before, limit)
+	ret0, _ := ret[0].([]habit_share.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockHabitsDatabaseMockRecorder) GetComments(habitId, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockHabitsDatabase)(nil).GetComments), habitId, before, limit)
+}
+
+// GetFeed mocks base method.
+func (m *MockHabitsDatabase) GetFeed(user string, before int64, limit int) ([]habit_share.Event, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", user, before, limit)
+	ret0, _ := ret[0].([]habit_share.Event)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockHabitsDatabaseMockRecorder) GetFeed(user, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockHabitsDatabase)(nil).GetFeed), user, before, limit)
+}
+
 // GetHabit mocks base method.
 func (m *MockHabitsDatabase) GetHabit(id string) (habit_share.Habit, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +403,36 @@ func (mr *MockHabitsDatabaseMockRecorder) GetHabit(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).GetHabit), id)
 }
 
+// GetInvitation mocks base method.
+func (m *MockHabitsDatabase) GetInvitation(id string) (habit_share.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", id)
+	ret0, _ := ret[0].(habit_share.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockHabitsDatabaseMockRecorder) GetInvitation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockHabitsDatabase)(nil).GetInvitation), id)
+}
+
+// GetMutedHabits mocks base method.
+func (m *MockHabitsDatabase) GetMutedHabits(user string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutedHabits", user)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutedHabits indicates an expected call of GetMutedHabits.
+func (mr *MockHabitsDatabaseMockRecorder) GetMutedHabits(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutedHabits", reflect.TypeOf((*MockHabitsDatabase)(nil).GetMutedHabits), user)
+}
+
 // GetMyHabits mocks base method.
 func (m *MockHabitsDatabase) GetMyHabits(owner string, limit int, archived bool) ([]habit_share.Habit, error) {
 	m.ctrl.T.Helper()
@@ -138,19 +448,124 @@ func (mr *MockHabitsDatabaseMockRecorder) GetMyHabits(owner, limit, archived int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyHabits", reflect.TypeOf((*MockHabitsDatabase)(nil).GetMyHabits), owner, limit, archived)
 }
 
-// GetScore mocks base method.
-func (m *MockHabitsDatabase) GetScore(habitId string) (int, error) {
+// GetNotification mocks base method.
+func (m *MockHabitsDatabase) GetNotification(user, id string) (habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotification", user, id)
+	ret0, _ := ret[0].(habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotification indicates an expected call of GetNotification.
+func (mr *MockHabitsDatabaseMockRecorder) GetNotification(user, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotification", reflect.TypeOf((*MockHabitsDatabase)(nil).GetNotification), user, id)
+}
+
+// GetNotifications mocks base method.
+func (m *MockHabitsDatabase) GetNotifications(user string, limit int, unread bool) ([]habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", user, limit, unread)
+	ret0, _ := ret[0].([]habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockHabitsDatabaseMockRecorder) GetNotifications(user, limit, unread interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockHabitsDatabase)(nil).GetNotifications), user, limit, unread)
+}
+
+// GetNotificationsSince mocks base method.
+func (m *MockHabitsDatabase) GetNotificationsSince(user string, since time.Time) ([]habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsSince", user, since)
+	ret0, _ := ret[0].([]habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationsSince indicates an expected call of GetNotificationsSince.
+func (mr *MockHabitsDatabaseMockRecorder) GetNotificationsSince(user, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsSince", reflect.TypeOf((*MockHabitsDatabase)(nil).GetNotificationsSince), user, since)
+}
+
+// GetPublicLink mocks base method.
+func (m *MockHabitsDatabase) GetPublicLink(token string) (habit_share.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicLink", token)
+	ret0, _ := ret[0].(habit_share.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicLink indicates an expected call of GetPublicLink.
+func (mr *MockHabitsDatabaseMockRecorder) GetPublicLink(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicLink", reflect.TypeOf((*MockHabitsDatabase)(nil).GetPublicLink), token)
+}
+
+// GetPublicLinks mocks base method.
+func (m *MockHabitsDatabase) GetPublicLinks(habitId string) ([]habit_share.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicLinks", habitId)
+	ret0, _ := ret[0].([]habit_share.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicLinks indicates an expected call of GetPublicLinks.
+func (mr *MockHabitsDatabaseMockRecorder) GetPublicLinks(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicLinks", reflect.TypeOf((*MockHabitsDatabase)(nil).GetPublicLinks), habitId)
+}
+
+// GetReactions mocks base method.
+func (m *MockHabitsDatabase) GetReactions(habitId string) ([]habit_share.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactions", habitId)
+	ret0, _ := ret[0].([]habit_share.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactions indicates an expected call of GetReactions.
+func (mr *MockHabitsDatabaseMockRecorder) GetReactions(habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockHabitsDatabase)(nil).GetReactions), habitId)
+}
+
+// GetReceivedInvitations mocks base method.
+func (m *MockHabitsDatabase) GetReceivedInvitations(to string) ([]habit_share.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedInvitations", to)
+	ret0, _ := ret[0].([]habit_share.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedInvitations indicates an expected call of GetReceivedInvitations.
+func (mr *MockHabitsDatabaseMockRecorder) GetReceivedInvitations(to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedInvitations", reflect.TypeOf((*MockHabitsDatabase)(nil).GetReceivedInvitations), to)
+}
+
+// GetSentInvitations mocks base method.
+func (m *MockHabitsDatabase) GetSentInvitations(from string) ([]habit_share.Invitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetScore", habitId)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetSentInvitations", from)
+	ret0, _ := ret[0].([]habit_share.Invitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetScore indicates an expected call of GetScore.
-func (mr *MockHabitsDatabaseMockRecorder) GetScore(habitId interface{}) *gomock.Call {
+// GetSentInvitations indicates an expected call of GetSentInvitations.
+func (mr *MockHabitsDatabaseMockRecorder) GetSentInvitations(from interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScore", reflect.TypeOf((*MockHabitsDatabase)(nil).GetScore), habitId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentInvitations", reflect.TypeOf((*MockHabitsDatabase)(nil).GetSentInvitations), from)
 }
 
 // GetSharedHabits mocks base method.
@@ -168,44 +583,575 @@ func (mr *MockHabitsDatabaseMockRecorder) GetSharedHabits(owner, limit interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedHabits", reflect.TypeOf((*MockHabitsDatabase)(nil).GetSharedHabits), owner, limit)
 }
 
-// SetHabit mocks base method.
-func (m *MockHabitsDatabase) SetHabit(habitId string, updatedHabit habit_share.Habit) error {
+// GetUnarchivedHabits mocks base method.
+func (m *MockHabitsDatabase) GetUnarchivedHabits() ([]habit_share.Habit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetHabit", habitId, updatedHabit)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetUnarchivedHabits")
+	ret0, _ := ret[0].([]habit_share.Habit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetHabit indicates an expected call of SetHabit.
-func (mr *MockHabitsDatabaseMockRecorder) SetHabit(habitId, updatedHabit interface{}) *gomock.Call {
+// GetUnarchivedHabits indicates an expected call of GetUnarchivedHabits.
+func (mr *MockHabitsDatabaseMockRecorder) GetUnarchivedHabits() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).SetHabit), habitId, updatedHabit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnarchivedHabits", reflect.TypeOf((*MockHabitsDatabase)(nil).GetUnarchivedHabits))
 }
 
-// ShareHabit mocks base method.
-func (m *MockHabitsDatabase) ShareHabit(habitId, friend string) error {
+// GetUnfinishedChallenges mocks base method.
+func (m *MockHabitsDatabase) GetUnfinishedChallenges() ([]habit_share.Challenge, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShareHabit", habitId, friend)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetUnfinishedChallenges")
+	ret0, _ := ret[0].([]habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ShareHabit indicates an expected call of ShareHabit.
-func (mr *MockHabitsDatabaseMockRecorder) ShareHabit(habitId, friend interface{}) *gomock.Call {
+// GetUnfinishedChallenges indicates an expected call of GetUnfinishedChallenges.
+func (mr *MockHabitsDatabaseMockRecorder) GetUnfinishedChallenges() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).ShareHabit), habitId, friend)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnfinishedChallenges", reflect.TypeOf((*MockHabitsDatabase)(nil).GetUnfinishedChallenges))
 }
 
-// UnShareHabit mocks base method.
-func (m *MockHabitsDatabase) UnShareHabit(habitId, friend string) error {
+// GetUserSettings mocks base method.
+func (m *MockHabitsDatabase) GetUserSettings(user string) (habit_share.UserSettings, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnShareHabit", habitId, friend)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetUserSettings", user)
+	ret0, _ := ret[0].(habit_share.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UnShareHabit indicates an expected call of UnShareHabit.
+// GetUserSettings indicates an expected call of GetUserSettings.
+func (mr *MockHabitsDatabaseMockRecorder) GetUserSettings(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockHabitsDatabase)(nil).GetUserSettings), user)
+}
+
+// GetVacations mocks base method.
+func (m *MockHabitsDatabase) GetVacations(owner string) ([]habit_share.Vacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVacations", owner)
+	ret0, _ := ret[0].([]habit_share.Vacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVacations indicates an expected call of GetVacations.
+func (mr *MockHabitsDatabaseMockRecorder) GetVacations(owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVacations", reflect.TypeOf((*MockHabitsDatabase)(nil).GetVacations), owner)
+}
+
+// LinkChallengeHabit mocks base method.
+func (m *MockHabitsDatabase) LinkChallengeHabit(id, participant, previous, habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkChallengeHabit", id, participant, previous, habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkChallengeHabit indicates an expected call of LinkChallengeHabit.
+func (mr *MockHabitsDatabaseMockRecorder) LinkChallengeHabit(id, participant, previous, habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkChallengeHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).LinkChallengeHabit), id, participant, previous, habitId)
+}
+
+// MuteHabit mocks base method.
+func (m *MockHabitsDatabase) MuteHabit(user, habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteHabit", user, habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteHabit indicates an expected call of MuteHabit.
+func (mr *MockHabitsDatabaseMockRecorder) MuteHabit(user, habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).MuteHabit), user, habitId)
+}
+
+// SetChallenge mocks base method.
+func (m *MockHabitsDatabase) SetChallenge(id string, updatedChallenge habit_share.Challenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChallenge", id, updatedChallenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChallenge indicates an expected call of SetChallenge.
+func (mr *MockHabitsDatabaseMockRecorder) SetChallenge(id, updatedChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChallenge", reflect.TypeOf((*MockHabitsDatabase)(nil).SetChallenge), id, updatedChallenge)
+}
+
+// SetComment mocks base method.
+func (m *MockHabitsDatabase) SetComment(habitId string, updatedComment habit_share.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetComment", habitId, updatedComment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetComment indicates an expected call of SetComment.
+func (mr *MockHabitsDatabaseMockRecorder) SetComment(habitId, updatedComment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetComment", reflect.TypeOf((*MockHabitsDatabase)(nil).SetComment), habitId, updatedComment)
+}
+
+// SetHabit mocks base method.
+func (m *MockHabitsDatabase) SetHabit(habitId string, updatedHabit habit_share.Habit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHabit", habitId, updatedHabit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHabit indicates an expected call of SetHabit.
+func (mr *MockHabitsDatabaseMockRecorder) SetHabit(habitId, updatedHabit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).SetHabit), habitId, updatedHabit)
+}
+
+// SetNotification mocks base method.
+func (m *MockHabitsDatabase) SetNotification(user string, updatedNotification habit_share.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotification", user, updatedNotification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotification indicates an expected call of SetNotification.
+func (mr *MockHabitsDatabaseMockRecorder) SetNotification(user, updatedNotification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotification", reflect.TypeOf((*MockHabitsDatabase)(nil).SetNotification), user, updatedNotification)
+}
+
+// SetUserSettings mocks base method.
+func (m *MockHabitsDatabase) SetUserSettings(user string, settings habit_share.UserSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserSettings", user, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserSettings indicates an expected call of SetUserSettings.
+func (mr *MockHabitsDatabaseMockRecorder) SetUserSettings(user, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserSettings", reflect.TypeOf((*MockHabitsDatabase)(nil).SetUserSettings), user, settings)
+}
+
+// ShareHabit mocks base method.
+func (m *MockHabitsDatabase) ShareHabit(habitId, friend string, permission habit_share.Permission, expires *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareHabit", habitId, friend, permission, expires)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareHabit indicates an expected call of ShareHabit.
+func (mr *MockHabitsDatabaseMockRecorder) ShareHabit(habitId, friend, permission, expires interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).ShareHabit), habitId, friend, permission, expires)
+}
+
+// UnShareHabit mocks base method.
+func (m *MockHabitsDatabase) UnShareHabit(habitId, friend string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnShareHabit", habitId, friend)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnShareHabit indicates an expected call of UnShareHabit.
 func (mr *MockHabitsDatabaseMockRecorder) UnShareHabit(habitId, friend interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnShareHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).UnShareHabit), habitId, friend)
 }
+
+// UnmuteHabit mocks base method.
+func (m *MockHabitsDatabase) UnmuteHabit(user, habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteHabit", user, habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteHabit indicates an expected call of UnmuteHabit.
+func (mr *MockHabitsDatabaseMockRecorder) UnmuteHabit(user, habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteHabit", reflect.TypeOf((*MockHabitsDatabase)(nil).UnmuteHabit), user, habitId)
+}
+
+// MockChallengesDatabase is a mock of ChallengesDatabase interface.
+type MockChallengesDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockChallengesDatabaseMockRecorder
+}
+
+// MockChallengesDatabaseMockRecorder is the mock recorder for MockChallengesDatabase.
+type MockChallengesDatabaseMockRecorder struct {
+	mock *MockChallengesDatabase
+}
+
+// NewMockChallengesDatabase creates a new mock instance.
+func NewMockChallengesDatabase(ctrl *gomock.Controller) *MockChallengesDatabase {
+	mock := &MockChallengesDatabase{ctrl: ctrl}
+	mock.recorder = &MockChallengesDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChallengesDatabase) EXPECT() *MockChallengesDatabaseMockRecorder {
+	return m.recorder
+}
+
+// CreateChallenge mocks base method.
+func (m *MockChallengesDatabase) CreateChallenge(newChallenge habit_share.Challenge) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", newChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockChallengesDatabaseMockRecorder) CreateChallenge(newChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockChallengesDatabase)(nil).CreateChallenge), newChallenge)
+}
+
+// GetChallenge mocks base method.
+func (m *MockChallengesDatabase) GetChallenge(id string) (habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", id)
+	ret0, _ := ret[0].(habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockChallengesDatabaseMockRecorder) GetChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockChallengesDatabase)(nil).GetChallenge), id)
+}
+
+// GetChallenges mocks base method.
+func (m *MockChallengesDatabase) GetChallenges(participant string) ([]habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenges", participant)
+	ret0, _ := ret[0].([]habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenges indicates an expected call of GetChallenges.
+func (mr *MockChallengesDatabaseMockRecorder) GetChallenges(participant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenges", reflect.TypeOf((*MockChallengesDatabase)(nil).GetChallenges), participant)
+}
+
+// GetUnfinishedChallenges mocks base method.
+func (m *MockChallengesDatabase) GetUnfinishedChallenges() ([]habit_share.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnfinishedChallenges")
+	ret0, _ := ret[0].([]habit_share.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnfinishedChallenges indicates an expected call of GetUnfinishedChallenges.
+func (mr *MockChallengesDatabaseMockRecorder) GetUnfinishedChallenges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnfinishedChallenges", reflect.TypeOf((*MockChallengesDatabase)(nil).GetUnfinishedChallenges))
+}
+
+// LinkChallengeHabit mocks base method.
+func (m *MockChallengesDatabase) LinkChallengeHabit(id, participant, previous, habitId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkChallengeHabit", id, participant, previous, habitId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkChallengeHabit indicates an expected call of LinkChallengeHabit.
+func (mr *MockChallengesDatabaseMockRecorder) LinkChallengeHabit(id, participant, previous, habitId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkChallengeHabit", reflect.TypeOf((*MockChallengesDatabase)(nil).LinkChallengeHabit), id, participant, previous, habitId)
+}
+
+// SetChallenge mocks base method.
+func (m *MockChallengesDatabase) SetChallenge(id string, updatedChallenge habit_share.Challenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChallenge", id, updatedChallenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChallenge indicates an expected call of SetChallenge.
+func (mr *MockChallengesDatabaseMockRecorder) SetChallenge(id, updatedChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChallenge", reflect.TypeOf((*MockChallengesDatabase)(nil).SetChallenge), id, updatedChallenge)
+}
+
+// MockCommentsDatabase is a mock of CommentsDatabase interface.
+type MockCommentsDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentsDatabaseMockRecorder
+}
+
+// MockCommentsDatabaseMockRecorder is the mock recorder for MockCommentsDatabase.
+type MockCommentsDatabaseMockRecorder struct {
+	mock *MockCommentsDatabase
+}
+
+// NewMockCommentsDatabase creates a new mock instance.
+func NewMockCommentsDatabase(ctrl *gomock.Controller) *MockCommentsDatabase {
+	mock := &MockCommentsDatabase{ctrl: ctrl}
+	mock.recorder = &MockCommentsDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentsDatabase) EXPECT() *MockCommentsDatabaseMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentsDatabase) CreateComment(newComment habit_share.Comment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", newComment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentsDatabaseMockRecorder) CreateComment(newComment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentsDatabase)(nil).CreateComment), newComment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentsDatabase) DeleteComment(habitId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", habitId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentsDatabaseMockRecorder) DeleteComment(habitId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentsDatabase)(nil).DeleteComment), habitId, id)
+}
+
+// GetComment mocks base method.
+func (m *MockCommentsDatabase) GetComment(habitId, id string) (habit_share.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", habitId, id)
+	ret0, _ := ret[0].(habit_share.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockCommentsDatabaseMockRecorder) GetComment(habitId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockCommentsDatabase)(nil).GetComment), habitId, id)
+}
+
+// GetComments mocks base method.
+func (m *MockCommentsDatabase) GetComments(habitId string, before time.Time, limit int) ([]habit_share.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", habitId, before, limit)
+	ret0, _ := ret[0].([]habit_share.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentsDatabaseMockRecorder) GetComments(habitId, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentsDatabase)(nil).GetComments), habitId, before, limit)
+}
+
+// SetComment mocks base method.
+func (m *MockCommentsDatabase) SetComment(habitId string, updatedComment habit_share.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetComment", habitId, updatedComment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetComment indicates an expected call of SetComment.
+func (mr *MockCommentsDatabaseMockRecorder) SetComment(habitId, updatedComment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetComment", reflect.TypeOf((*MockCommentsDatabase)(nil).SetComment), habitId, updatedComment)
+}
+
+// MockEventsDatabase is a mock of EventsDatabase interface.
+type MockEventsDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsDatabaseMockRecorder
+}
+
+// MockEventsDatabaseMockRecorder is the mock recorder for MockEventsDatabase.
+type MockEventsDatabaseMockRecorder struct {
+	mock *MockEventsDatabase
+}
+
+// NewMockEventsDatabase creates a new mock instance.
+func NewMockEventsDatabase(ctrl *gomock.Controller) *MockEventsDatabase {
+	mock := &MockEventsDatabase{ctrl: ctrl}
+	mock.recorder = &MockEventsDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsDatabase) EXPECT() *MockEventsDatabaseMockRecorder {
+	return m.recorder
+}
+
+// AppendEvent mocks base method.
+func (m *MockEventsDatabase) AppendEvent(newEvent habit_share.Event) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvent", newEvent)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendEvent indicates an expected call of AppendEvent.
+func (mr *MockEventsDatabaseMockRecorder) AppendEvent(newEvent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvent", reflect.TypeOf((*MockEventsDatabase)(nil).AppendEvent), newEvent)
+}
+
+// GetFeed mocks base method.
+func (m *MockEventsDatabase) GetFeed(user string, before int64, limit int) ([]habit_share.Event, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", user, before, limit)
+	ret0, _ := ret[0].([]habit_share.Event)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockEventsDatabaseMockRecorder) GetFeed(user, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockEventsDatabase)(nil).GetFeed), user, before, limit)
+}
+
+// MockNotificationsDatabase is a mock of NotificationsDatabase interface.
+type MockNotificationsDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationsDatabaseMockRecorder
+}
+
+// MockNotificationsDatabaseMockRecorder is the mock recorder for MockNotificationsDatabase.
+type MockNotificationsDatabaseMockRecorder struct {
+	mock *MockNotificationsDatabase
+}
+
+// NewMockNotificationsDatabase creates a new mock instance.
+func NewMockNotificationsDatabase(ctrl *gomock.Controller) *MockNotificationsDatabase {
+	mock := &MockNotificationsDatabase{ctrl: ctrl}
+	mock.recorder = &MockNotificationsDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationsDatabase) EXPECT() *MockNotificationsDatabaseMockRecorder {
+	return m.recorder
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationsDatabase) CreateNotification(newNotification habit_share.Notification) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", newNotification)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationsDatabaseMockRecorder) CreateNotification(newNotification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationsDatabase)(nil).CreateNotification), newNotification)
+}
+
+// DeleteNotification mocks base method.
+func (m *MockNotificationsDatabase) DeleteNotification(user, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotification", user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotification indicates an expected call of DeleteNotification.
+func (mr *MockNotificationsDatabaseMockRecorder) DeleteNotification(user, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockNotificationsDatabase)(nil).DeleteNotification), user, id)
+}
+
+// GetNotification mocks base method.
+func (m *MockNotificationsDatabase) GetNotification(user, id string) (habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotification", user, id)
+	ret0, _ := ret[0].(habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotification indicates an expected call of GetNotification.
+func (mr *MockNotificationsDatabaseMockRecorder) GetNotification(user, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotification", reflect.TypeOf((*MockNotificationsDatabase)(nil).GetNotification), user, id)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationsDatabase) GetNotifications(user string, limit int, unread bool) ([]habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", user, limit, unread)
+	ret0, _ := ret[0].([]habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationsDatabaseMockRecorder) GetNotifications(user, limit, unread interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationsDatabase)(nil).GetNotifications), user, limit, unread)
+}
+
+// GetNotificationsSince mocks base method.
+func (m *MockNotificationsDatabase) GetNotificationsSince(user string, since time.Time) ([]habit_share.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsSince", user, since)
+	ret0, _ := ret[0].([]habit_share.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationsSince indicates an expected call of GetNotificationsSince.
+func (mr *MockNotificationsDatabaseMockRecorder) GetNotificationsSince(user, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsSince", reflect.TypeOf((*MockNotificationsDatabase)(nil).GetNotificationsSince), user, since)
+}
+
+// SetNotification mocks base method.
+func (m *MockNotificationsDatabase) SetNotification(user string, updatedNotification habit_share.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotification", user, updatedNotification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotification indicates an expected call of SetNotification.
+func (mr *MockNotificationsDatabaseMockRecorder) SetNotification(user, updatedNotification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotification", reflect.TypeOf((*MockNotificationsDatabase)(nil).SetNotification), user, updatedNotification)
+}
@@ -0,0 +1,211 @@
+package habit_share
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+)
+
+var NotificationNotFoundError = errors.New("Notification could not be found")
+
+const (
+	// From shared the habit with the user
+	NotificationHabitShared = "HABIT_SHARED"
+	// From stopped sharing the habit with the user
+	NotificationHabitUnshared = "HABIT_UNSHARED"
+	// the user's streak ends unless they do the habit today
+	NotificationStreakAtRisk = "STREAK_AT_RISK"
+)
+
+// Notification tells User that something happened. Every notification is kept
+// in the user's inbox and also handed to the App's Notifiers.
+type Notification struct {
+	Id        string
+	User      string
+	Type      string
+	HabitId   string
+	HabitName string
+	// who caused it, empty when no one did
+	From    string
+	Created time.Time
+	Read    bool
+}
+
+// Notifier delivers notifications somewhere other than the inbox, like email
+// or push
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// notify puts the notification in the user's inbox then hands it to each
+// Notifier. Delivery is best effort as the inbox already has it.
+func (a *App) notify(habit Habit, notification Notification) error {
+	notification.HabitId = habit.Id
+	notification.HabitName = habit.Name
+	notification.Created = time.Now()
+	id, err := a.Db.CreateNotification(notification)
+	if err != nil {
+		return err
+	}
+	notification.Id = id
+
+	for _, notifier := range a.Notifiers {
+		if err := notifier.Notify(notification); err != nil {
+			log.Printf("Failed to deliver notification %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// GetNotifications returns up to limit of the current user's notifications,
+// newest first. unread leaves out those already read.
+func (a *App) GetNotifications(limit int, unread bool) ([]Notification, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetNotifications(user, limit, unread)
+}
+
+func (a *App) MarkNotificationRead(id string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	notification, err := a.Db.GetNotification(user, id)
+	if err != nil {
+		return err
+	}
+	if notification.Read {
+		return nil
+	}
+	notification.Read = true
+	return a.Db.SetNotification(user, notification)
+}
+
+func (a *App) DeleteNotification(id string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	return a.Db.DeleteNotification(user, id)
+}
+
+// WarnStreaksAtRisk lets whoever keeps each habit scored by its streak know
+// when the streak ends unless they do it today, returning how many were
+// warned. Each is warned at most once a day. It doesn't need a current user.
+func (a *App) WarnStreaksAtRisk() (int, error) {
+	habits, err := a.Db.GetUnarchivedHabits()
+	if err != nil {
+		return 0, err
+	}
+
+	warned := 0
+	for _, habit := range habits {
+		habitWarned, err := a.warnStreakAtRisk(habit)
+		warned += habitWarned
+		if err != nil {
+			return warned, err
+		}
+	}
+
+	return warned, nil
+}
+
+func (a *App) warnStreakAtRisk(habit Habit) (int, error) {
+	strategy, err := ScoringFor(habit.Scoring)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := strategy.(StreakScore); !ok {
+		return 0, nil
+	}
+	// only worth warning about a streak that has started
+	if score, err := a.score(habit); err != nil || score == 0 {
+		return 0, err
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return 0, err
+	}
+	vacations, err := a.Db.GetVacations(habit.Owner)
+	if err != nil {
+		return 0, err
+	}
+	// only the current period decides whether the streak is at risk
+	activities, _, err := a.Db.GetActivities(
+		habit.Id,
+		Time{Time: habit.At(today.Time).Period.Start(today.Time)},
+		Time{Time: today.AddDate(0, 0, 1)},
+		math.MaxInt32,
+	)
+	if err != nil {
+		return 0, err
+	}
+	activities = habit.CombineActivities(activities)
+	if !streakAtRisk(habit, activities, vacations, today.Time) {
+		return 0, nil
+	}
+
+	recipients := habit.Members
+	if !habit.IsGroup() {
+		recipients = []string{habit.Owner}
+	}
+	warnedSince := time.Now().Add(-24 * time.Hour)
+	warned := 0
+	for _, recipient := range recipients {
+		notifications, err := a.Db.GetNotificationsSince(recipient, warnedSince)
+		if err != nil {
+			return warned, err
+		}
+		alreadyWarned := false
+		for _, notification := range notifications {
+			if notification.Type == NotificationStreakAtRisk && notification.HabitId == habit.Id {
+				alreadyWarned = true
+				break
+			}
+		}
+		if alreadyWarned {
+			continue
+		}
+
+		if err := a.notify(habit, Notification{User: recipient, Type: NotificationStreakAtRisk}); err != nil {
+			return warned, err
+		}
+		warned++
+	}
+	return warned, nil
+}
+
+// streakAtRisk is whether the period breaks the streak unless the habit is
+// done today. activities must be sorted oldest first.
+func streakAtRisk(habit Habit, activities []Activity, vacations []Vacation, today time.Time) bool {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	schedule := habit.At(today).Schedule
+	if !schedule.IsWeekly() {
+		if !schedule.IsDue(today) || OnVacation(vacations, today, tomorrow) {
+			return false
+		}
+		for i := len(activities) - 1; i >= 0 && !activities[i].Logged.Before(today); i-- {
+			if !activities[i].Logged.Before(tomorrow) {
+				continue
+			}
+			switch activities[i].Status {
+			case ActivitySuccess, ActivityMinimum, ActivityExcused:
+				return false
+			}
+		}
+		return true
+	}
+
+	periods := EvaluatePeriods(habit, activities, vacations, today)
+	current := periods[len(periods)-1]
+	return !current.Met && !current.Vacation && !current.End.After(tomorrow)
+}
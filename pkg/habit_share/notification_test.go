@@ -0,0 +1,131 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+type recordingNotifier struct {
+	delivered *[]habit_share.Notification
+}
+
+func (n recordingNotifier) Notify(notification habit_share.Notification) error {
+	*n.delivered = append(*n.delivered, notification)
+	return nil
+}
+
+func TestNotifications(t *testing.T) {
+	newApps := func(t *testing.T) (app *habit_share.App, friendApp *habit_share.App, delivered *[]habit_share.Notification) {
+		db := newTestDb()
+		delivered = &[]habit_share.Notification{}
+		notifiers := []habit_share.Notifier{recordingNotifier{delivered: delivered}}
+		app = &habit_share.App{Db: db, Auth: testAuth{}, Notifiers: notifiers}
+		friendApp = &habit_share.App{Db: db, Auth: otherAuth{}, Notifiers: notifiers}
+		return app, friendApp, delivered
+	}
+
+	t.Run("should tell friends when sharing starts and stops", func(t *testing.T) {
+		app, friendApp, delivered := newApps(t)
+
+		habitId, _ := app.CreateHabit("mine", 3, habit_share.Period{})
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		// changing the permission isn't news
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionLog, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if err := app.UnShareHabit(habitId, "friend"); err != nil {
+			t.Fatal("UnShareHabit returned error unexpectedly:", err)
+		}
+
+		notifications, err := friendApp.GetNotifications(10, false)
+		if err != nil {
+			t.Fatal("GetNotifications returned error unexpectedly:", err)
+		}
+		if len(notifications) != 2 ||
+			notifications[0].Type != habit_share.NotificationHabitUnshared ||
+			notifications[1].Type != habit_share.NotificationHabitShared ||
+			notifications[1].From != "testUser" || notifications[1].HabitName != "mine" {
+			t.Errorf("expected shared then unshared newest first got %+v", notifications)
+		}
+		if len(*delivered) != 2 {
+			t.Errorf("expected both to be delivered got %v", *delivered)
+		}
+	})
+
+	t.Run("should mark read and delete", func(t *testing.T) {
+		app, friendApp, _ := newApps(t)
+
+		habitId, _ := app.CreateHabit("mine", 3, habit_share.Period{})
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		notifications, _ := friendApp.GetNotifications(10, true)
+		if len(notifications) != 1 {
+			t.Fatalf("expected 1 unread notification got %v", notifications)
+		}
+		id := notifications[0].Id
+
+		if err := app.MarkNotificationRead(id); err != habit_share.NotificationNotFoundError {
+			t.Error("expected NotificationNotFoundError got:", err)
+		}
+		if err := friendApp.MarkNotificationRead(id); err != nil {
+			t.Fatal("MarkNotificationRead returned error unexpectedly:", err)
+		}
+		if notifications, _ := friendApp.GetNotifications(10, true); len(notifications) != 0 {
+			t.Errorf("expected no unread notifications got %v", notifications)
+		}
+
+		if err := friendApp.DeleteNotification(id); err != nil {
+			t.Fatal("DeleteNotification returned error unexpectedly:", err)
+		}
+		if notifications, _ := friendApp.GetNotifications(10, false); len(notifications) != 0 {
+			t.Errorf("expected no notifications got %v", notifications)
+		}
+	})
+
+	t.Run("should warn once when a streak is at risk", func(t *testing.T) {
+		app, _, _ := newApps(t)
+
+		habitId, _ := app.CreateHabit("daily", 1, habit_share.Period{Unit: habit_share.PeriodDay, Length: 1})
+		today, _ := app.HabitToday(habitId)
+		yesterday := habit_share.Time{Time: today.AddDate(0, 0, -1)}
+		if _, err := app.CreateActivity(habitId, yesterday, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		// scoring alone doesn't warn
+		if _, err := app.GetScore(habitId); err != nil {
+			t.Fatal("GetScore returned error unexpectedly:", err)
+		}
+		if notifications, _ := app.GetNotifications(10, false); len(notifications) != 0 {
+			t.Errorf("expected no warnings from scoring got %+v", notifications)
+		}
+
+		for _, expected := range []int{1, 0} {
+			if warned, err := app.WarnStreaksAtRisk(); err != nil || warned != expected {
+				t.Fatalf("expected %d warned got %d %v", expected, warned, err)
+			}
+		}
+		notifications, _ := app.GetNotifications(10, false)
+		if len(notifications) != 1 || notifications[0].Type != habit_share.NotificationStreakAtRisk {
+			t.Errorf("expected a single warning got %+v", notifications)
+		}
+	})
+
+	t.Run("should not warn once done today", func(t *testing.T) {
+		app, _, _ := newApps(t)
+
+		habitId, _ := app.CreateHabit("daily", 1, habit_share.Period{Unit: habit_share.PeriodDay, Length: 1})
+		today, _ := app.HabitToday(habitId)
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		if warned, err := app.WarnStreaksAtRisk(); err != nil || warned != 0 {
+			t.Fatalf("expected none warned got %d %v", warned, err)
+		}
+	})
+}
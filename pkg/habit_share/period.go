@@ -0,0 +1,137 @@
+package habit_share
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	PeriodDay   = "DAY"
+	PeriodWeek  = "WEEK"
+	PeriodMonth = "MONTH"
+	PeriodYear  = "YEAR"
+)
+
+// Periods longer than this are more likely a typo than a real habit
+const maxPeriodLength = 366
+
+// Periods of more than one day are counted from this date so every N days
+// lands on the same days no matter when you ask. It's a Monday which keeps
+// multi-week periods starting on Mondays like the single week ones.
+var periodEpoch = time.Date(1970, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+// Period is the window Frequency is counted over, e.g. 2 times every MONTH.
+// The zero value is a single week so habits saved before periods existed keep
+// working.
+type Period struct {
+	Unit   string
+	Length int
+}
+
+// NewPeriod validates the unit and length. A length of 0 is taken as 1.
+func NewPeriod(unit string, length int) (Period, error) {
+	if unit != PeriodDay &&
+		unit != PeriodWeek &&
+		unit != PeriodMonth &&
+		unit != PeriodYear {
+		return Period{}, &InputError{StringToParse: unit}
+	}
+	if length == 0 {
+		length = 1
+	}
+	if length < 1 || length > maxPeriodLength {
+		return Period{}, &InputError{StringToParse: fmt.Sprint(length)}
+	}
+
+	return Period{Unit: unit, Length: length}, nil
+}
+
+func (p Period) normalised() Period {
+	if p.Unit == "" {
+		p.Unit = PeriodWeek
+	}
+	if p.Length < 1 {
+		p.Length = 1
+	}
+	return p
+}
+
+// IsWeekly is true for the default of a single week
+func (p Period) IsWeekly() bool {
+	p = p.normalised()
+	return p.Unit == PeriodWeek && p.Length == 1
+}
+
+// MaxFrequency is the most times a habit can be done in the period assuming
+// at most once a day
+func (p Period) MaxFrequency() int {
+	p = p.normalised()
+	switch p.Unit {
+	case PeriodDay:
+		return p.Length
+	case PeriodMonth:
+		return 31 * p.Length
+	case PeriodYear:
+		return 366 * p.Length
+	default:
+		return 7 * p.Length
+	}
+}
+
+// Start returns the first day of the period day falls in
+func (p Period) Start(day time.Time) time.Time {
+	p = p.normalised()
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch p.Unit {
+	case PeriodDay, PeriodWeek:
+		periodDays := p.Length
+		if p.Unit == PeriodWeek {
+			periodDays *= 7
+		}
+		// Sub would overflow a Duration for dates a few centuries away
+		days := int((day.Unix() - periodEpoch.Unix()) / (24 * 60 * 60))
+		return periodEpoch.AddDate(0, 0, floorDiv(days, periodDays)*periodDays)
+	case PeriodMonth:
+		months := day.Year()*12 + int(day.Month()) - 1
+		months = floorDiv(months, p.Length) * p.Length
+		return time.Date(months/12, time.Month(months%12+1), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		year := floorDiv(day.Year(), p.Length) * p.Length
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Next returns the start of the period after the one starting at start
+func (p Period) Next(start time.Time) time.Time {
+	return p.add(start, 1)
+}
+
+// Previous returns the start of the period before the one starting at start
+func (p Period) Previous(start time.Time) time.Time {
+	return p.add(start, -1)
+}
+
+func (p Period) add(start time.Time, n int) time.Time {
+	p = p.normalised()
+	switch p.Unit {
+	case PeriodDay:
+		return start.AddDate(0, 0, n*p.Length)
+	case PeriodMonth:
+		return start.AddDate(0, n*p.Length, 0)
+	case PeriodYear:
+		return start.AddDate(n*p.Length, 0, 0)
+	default:
+		return start.AddDate(0, 0, 7*n*p.Length)
+	}
+}
+
+// integer division rounding towards negative infinity so dates before the
+// epoch still land in the right period
+func floorDiv(a int, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,83 @@
+package habit_share
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Permission is what someone a habit is shared with can do to it. Each level
+// can do everything the ones before it can.
+type Permission string
+
+const (
+	// see the habit and its activities
+	PermissionView Permission = "VIEW"
+	// log and delete activities
+	PermissionLog Permission = "LOG"
+	// change the habit's settings
+	PermissionEdit Permission = "EDIT"
+)
+
+var permissionLevels = map[Permission]int{
+	PermissionView: 1,
+	PermissionLog:  2,
+	PermissionEdit: 3,
+}
+
+// ParsePermission checks name is a Permission. An empty name is PermissionView.
+func ParsePermission(name string) (Permission, error) {
+	if name == "" {
+		return PermissionView, nil
+	}
+	permission := Permission(name)
+	if _, ok := permissionLevels[permission]; !ok {
+		return "", &InputError{StringToParse: name}
+	}
+	return permission, nil
+}
+
+// Allows is true if p includes needed
+func (p Permission) Allows(needed Permission) bool {
+	return permissionLevels[p] >= permissionLevels[needed]
+}
+
+// UnmarshalJSON reads shares written before there were permissions, when
+// SharedWith held empty structs, as view-only
+func (p *Permission) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		*p = PermissionView
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	*p = Permission(name)
+	return nil
+}
+
+// habitPermissionCheck passes for the owner, members and anyone the habit is shared with
+// that has at least the needed permission
+func (a *App) habitPermissionCheck(habit Habit, needed Permission) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+	if habit.Owner == user || habit.HasMember(user) {
+		return nil
+	}
+
+	permission, ok := habit.SharedWith[user]
+	if !ok || !permission.Allows(needed) || habit.ShareExpired(user, time.Now()) {
+		return PermissionDeniedError
+	}
+	if blocked, err := a.blocked(habit.Owner, user); err != nil {
+		return err
+	} else if blocked {
+		return PermissionDeniedError
+	}
+
+	return nil
+}
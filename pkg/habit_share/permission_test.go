@@ -0,0 +1,94 @@
+package habit_share_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestPermissions(t *testing.T) {
+	newApps := func(t *testing.T, permission habit_share.Permission) (app *habit_share.App, friendApp *habit_share.App, habitId string) {
+		app, friendApp, habitId = newFriendApps(t)
+		if err := app.ShareHabit(habitId, "friend", permission, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		return app, friendApp, habitId
+	}
+
+	t.Run("should only let view-only shares look", func(t *testing.T) {
+		_, friendApp, habitId := newApps(t, "")
+		today, _ := friendApp.HabitToday(habitId)
+
+		if _, err := friendApp.GetHabit(habitId); err != nil {
+			t.Error("GetHabit returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+	})
+
+	t.Run("should let can-log shares log but not edit", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t, habit_share.PermissionLog)
+		today, _ := friendApp.HabitToday(habitId)
+
+		activityId, err := friendApp.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0)
+		if err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		if err := friendApp.DeleteActivity(habitId, activityId); err != nil {
+			t.Error("DeleteActivity returned error unexpectedly:", err)
+		}
+		if err := friendApp.ChangeName(habitId, "theirs"); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+
+		// sharing again changes the permission
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if _, err := friendApp.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+	})
+
+	t.Run("should let can-edit shares edit but not manage the habit", func(t *testing.T) {
+		_, friendApp, habitId := newApps(t, habit_share.PermissionEdit)
+
+		if err := friendApp.ChangeName(habitId, "ours"); err != nil {
+			t.Error("ChangeName returned error unexpectedly:", err)
+		}
+		if err := friendApp.ChangeFrequency(habitId, 5); err != nil {
+			t.Error("ChangeFrequency returned error unexpectedly:", err)
+		}
+		if err := friendApp.ArchiveHabit(habitId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+		if err := friendApp.ShareHabit(habitId, "testUser", habit_share.PermissionEdit, nil); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+	})
+
+	t.Run("should reject unknown permissions", func(t *testing.T) {
+		app, _, habitId := newApps(t, "")
+
+		if err := app.ShareHabit(habitId, "friend", "OWN", nil); err == nil {
+			t.Error("expected unknown permission to be rejected")
+		}
+	})
+
+	t.Run("should read shares from before permissions as view-only", func(t *testing.T) {
+		var habit habit_share.Habit
+		err := json.Unmarshal([]byte(`{"SharedWith": {"old": {}, "new": "EDIT"}}`), &habit)
+		if err != nil {
+			t.Fatal("Unmarshal returned error unexpectedly:", err)
+		}
+
+		if habit.SharedWith["old"] != habit_share.PermissionView {
+			t.Error("expected VIEW got", habit.SharedWith["old"])
+		}
+		if habit.SharedWith["new"] != habit_share.PermissionEdit {
+			t.Error("expected EDIT got", habit.SharedWith["new"])
+		}
+	})
+}
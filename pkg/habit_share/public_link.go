@@ -0,0 +1,147 @@
+package habit_share
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+var PublicLinkNotFoundError = errors.New("Public link could not be found")
+
+// how many days of activities a public link shows, today included
+const PublicActivityDays = 7
+
+// PublicLink lets anyone with the Token see the habit without logging in
+type PublicLink struct {
+	// random and long enough to not be guessed, it is all that's needed to view
+	// the habit
+	Token   string
+	HabitId string
+	Created time.Time
+}
+
+// PublicHabit is what a public link shows. It leaves out everything that
+// identifies the owner or who the habit is shared with.
+type PublicHabit struct {
+	Name          string
+	Description   string
+	Score         int
+	ScoreStrategy string
+	Activities    []PublicActivity
+}
+
+type PublicActivity struct {
+	Logged Time
+	Status string
+	Amount float64
+}
+
+func newPublicToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreatePublicLink makes a new link anyone can view the habit with
+func (a *App) CreatePublicLink(habitId string) (PublicLink, error) {
+	if err := a.habitIdOwnerCheck(habitId); err != nil {
+		return PublicLink{}, err
+	}
+
+	token, err := newPublicToken()
+	if err != nil {
+		return PublicLink{}, err
+	}
+	link := PublicLink{Token: token, HabitId: habitId, Created: time.Now()}
+	if err := a.Db.CreatePublicLink(link); err != nil {
+		return PublicLink{}, err
+	}
+
+	return link, nil
+}
+
+func (a *App) GetPublicLinks(habitId string) ([]PublicLink, error) {
+	if err := a.habitIdOwnerCheck(habitId); err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetPublicLinks(habitId)
+}
+
+// RevokePublicLink stops the link working
+func (a *App) RevokePublicLink(habitId string, token string) error {
+	if err := a.habitIdOwnerCheck(habitId); err != nil {
+		return err
+	}
+
+	link, err := a.Db.GetPublicLink(token)
+	if err != nil {
+		return err
+	}
+	if link.HabitId != habitId {
+		return PublicLinkNotFoundError
+	}
+
+	return a.Db.DeletePublicLink(token)
+}
+
+// GetPublicHabit returns what the link with token shows. No one needs to be
+// logged in so Auth isn't used.
+func (a *App) GetPublicHabit(token string) (PublicHabit, error) {
+	link, err := a.Db.GetPublicLink(token)
+	if err != nil {
+		return PublicHabit{}, err
+	}
+	habit, err := a.Db.GetHabit(link.HabitId)
+	if err != nil {
+		if err == HabitNotFoundError {
+			return PublicHabit{}, PublicLinkNotFoundError
+		}
+		return PublicHabit{}, err
+	}
+
+	score, err := a.score(habit)
+	if err != nil {
+		return PublicHabit{}, err
+	}
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return PublicHabit{}, err
+	}
+	// group habits have an activity a day for each member
+	limit := PublicActivityDays
+	if habit.IsGroup() {
+		limit *= len(habit.Members)
+	}
+	activities, _, err := a.Db.GetActivities(
+		habit.Id,
+		Time{Time: today.AddDate(0, 0, -(PublicActivityDays - 1))},
+		Time{Time: today.AddDate(0, 0, 1)},
+		limit,
+	)
+	if err != nil {
+		return PublicHabit{}, err
+	}
+
+	// the members of group habits aren't shown so neither are their activities
+	activities = habit.CombineActivities(activities)
+	publicActivities := make([]PublicActivity, 0, len(activities))
+	for _, activity := range activities {
+		publicActivities = append(publicActivities, PublicActivity{
+			Logged: activity.Logged,
+			Status: activity.Status,
+			Amount: activity.Amount,
+		})
+	}
+
+	return PublicHabit{
+		Name:          habit.Name,
+		Description:   habit.Description,
+		Score:         score,
+		ScoreStrategy: habit.ScoringName(),
+		Activities:    publicActivities,
+	}, nil
+}
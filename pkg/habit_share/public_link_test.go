@@ -0,0 +1,98 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestPublicLinks(t *testing.T) {
+	newApps := func(t *testing.T) (app *habit_share.App, publicApp *habit_share.App, habitId string) {
+		db := newTestDb()
+		app = &habit_share.App{Db: db, Auth: testAuth{}}
+		// no one is logged in to view public links
+		publicApp = &habit_share.App{Db: db}
+
+		habitId, err := app.CreateHabit("meditate", 3, habit_share.Period{})
+		if err != nil {
+			t.Fatal("CreateHabit returned error unexpectedly:", err)
+		}
+		return app, publicApp, habitId
+	}
+
+	t.Run("should show the habit without logging in", func(t *testing.T) {
+		app, publicApp, habitId := newApps(t)
+		today, _ := app.HabitToday(habitId)
+		if _, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0); err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+
+		link, err := app.CreatePublicLink(habitId)
+		if err != nil {
+			t.Fatal("CreatePublicLink returned error unexpectedly:", err)
+		}
+		if len(link.Token) < 32 {
+			t.Errorf("expected a long token got %q", link.Token)
+		}
+
+		habit, err := publicApp.GetPublicHabit(link.Token)
+		if err != nil {
+			t.Fatal("GetPublicHabit returned error unexpectedly:", err)
+		}
+		if habit.Name != "meditate" || habit.Score != 1 || len(habit.Activities) != 1 {
+			t.Errorf("unexpected public habit %+v", habit)
+		}
+	})
+
+	t.Run("should show the latest days of activities", func(t *testing.T) {
+		app, publicApp, habitId := newApps(t)
+		today, _ := app.HabitToday(habitId)
+		for days := 0; days <= habit_share.PublicActivityDays; days++ {
+			if _, err := app.CreateActivity(habitId, habit_share.Time{Time: today.AddDate(0, 0, -days)}, habit_share.ActivitySuccess, 0); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+		link, _ := app.CreatePublicLink(habitId)
+
+		habit, err := publicApp.GetPublicHabit(link.Token)
+		if err != nil {
+			t.Fatal("GetPublicHabit returned error unexpectedly:", err)
+		}
+		if len(habit.Activities) != habit_share.PublicActivityDays {
+			t.Fatalf("expected %d activities got %+v", habit_share.PublicActivityDays, habit.Activities)
+		}
+		for _, activity := range habit.Activities {
+			if !activity.Logged.After(today.AddDate(0, 0, -habit_share.PublicActivityDays)) {
+				t.Errorf("expected only the last %d days got %+v", habit_share.PublicActivityDays, habit.Activities)
+			}
+		}
+	})
+
+	t.Run("should stop revoked links working", func(t *testing.T) {
+		app, publicApp, habitId := newApps(t)
+
+		link, err := app.CreatePublicLink(habitId)
+		if err != nil {
+			t.Fatal("CreatePublicLink returned error unexpectedly:", err)
+		}
+		if links, _ := app.GetPublicLinks(habitId); len(links) != 1 {
+			t.Errorf("expected 1 link got %v", links)
+		}
+
+		if err := app.RevokePublicLink(habitId, link.Token); err != nil {
+			t.Fatal("RevokePublicLink returned error unexpectedly:", err)
+		}
+		if _, err := publicApp.GetPublicHabit(link.Token); err != habit_share.PublicLinkNotFoundError {
+			t.Error("expected PublicLinkNotFoundError got:", err)
+		}
+	})
+
+	t.Run("should only let the owner create links", func(t *testing.T) {
+		app, _, habitId := newApps(t)
+		friendApp := &habit_share.App{Db: app.Db, Auth: otherAuth{}}
+
+		if _, err := friendApp.CreatePublicLink(habitId); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package habit_share_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestQuantitativeHabits(t *testing.T) {
+	newApp := func(t *testing.T) (app *habit_share.App, habitId string, today habit_share.Time) {
+		app, _, habitId = newFriendApps(t)
+		if err := app.ChangeTarget(habitId, "pages", 30, 10); err != nil {
+			t.Fatal("ChangeTarget returned error unexpectedly:", err)
+		}
+		today, _ = app.HabitToday(habitId)
+		return app, habitId, today
+	}
+
+	t.Run("should derive the status from the amount", func(t *testing.T) {
+		app, habitId, today := newApp(t)
+
+		logged := []struct {
+			status string
+			amount float64
+		}{
+			{habit_share.ActivitySuccess, 5},
+			{habit_share.ActivityNotDone, 15},
+			{habit_share.ActivityNotDone, 30},
+			{habit_share.ActivityExcused, 40},
+		}
+		for i, activity := range logged {
+			day := habit_share.Time{Time: today.AddDate(0, 0, i-len(logged)+1)}
+			if _, err := app.CreateActivity(habitId, day, activity.status, activity.amount); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+
+		activities, _, err := app.GetActivities(habitId, habit_share.Time{Time: today.AddDate(0, 0, -len(logged))}, habit_share.Time{Time: today.AddDate(0, 0, 1)}, 10)
+		if err != nil {
+			t.Fatal("GetActivities returned error unexpectedly:", err)
+		}
+		expected := []habit_share.Activity{
+			{Status: habit_share.ActivityNotDone, Amount: 5},
+			{Status: habit_share.ActivityMinimum, Amount: 15},
+			{Status: habit_share.ActivitySuccess, Amount: 30},
+			// excuses don't need an amount
+			{Status: habit_share.ActivityExcused, Amount: 0},
+		}
+		if len(activities) != len(expected) {
+			t.Fatalf("expected %v got %v", expected, activities)
+		}
+		for i := range expected {
+			if activities[i].Status != expected[i].Status || activities[i].Amount != expected[i].Amount {
+				t.Errorf("expected %v got %v", expected[i], activities[i])
+			}
+		}
+	})
+
+	t.Run("should reject negative amounts", func(t *testing.T) {
+		app, habitId, today := newApp(t)
+
+		_, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, -1)
+		if inputError := (*habit_share.InputError)(nil); !errors.As(err, &inputError) {
+			t.Error("expected InputError got:", err)
+		}
+	})
+
+	t.Run("should reject amounts on habits that aren't quantitative", func(t *testing.T) {
+		app, _, habitId := newFriendApps(t)
+		today, _ := app.HabitToday(habitId)
+
+		_, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 2)
+		if inputError := (*habit_share.InputError)(nil); !errors.As(err, &inputError) {
+			t.Error("expected InputError got:", err)
+		}
+	})
+}
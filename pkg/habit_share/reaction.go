@@ -0,0 +1,92 @@
+package habit_share
+
+import (
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// long enough for emoji made of several code points
+const maxReactionLength = 16
+
+// Reaction is a cheer or emoji someone left on an activity
+type Reaction struct {
+	ActivityId string
+	User       string
+	Emoji      string
+	Created    time.Time
+}
+
+// findActivity looks for the activity with id among the habit's activities
+func (a *App) findActivity(habit Habit, id string) (Activity, error) {
+	activities, _, err := a.Db.GetActivities(habit.Id, Time{}, endOfTime, math.MaxInt32)
+	if err != nil {
+		return Activity{}, err
+	}
+	for _, activity := range activities {
+		if activity.Id == id {
+			return activity, nil
+		}
+	}
+
+	return Activity{}, ActivityNotFoundError
+}
+
+// React leaves emoji on the activity replacing any reaction the current user
+// already left on it
+func (a *App) React(habitId string, activityId string, emoji string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionView); err != nil {
+		return err
+	}
+
+	if emoji == "" || utf8.RuneCountInString(emoji) > maxReactionLength {
+		return &InputError{StringToParse: emoji}
+	}
+	if _, err := a.findActivity(habit, activityId); err != nil {
+		return err
+	}
+
+	return a.Db.AddReaction(habitId, Reaction{
+		ActivityId: activityId,
+		User:       user,
+		Emoji:      emoji,
+		Created:    time.Now(),
+	})
+}
+
+// Unreact removes the current user's reaction from the activity
+func (a *App) Unreact(habitId string, activityId string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionView); err != nil {
+		return err
+	}
+
+	return a.Db.DeleteReaction(habitId, activityId, user)
+}
+
+func (a *App) GetReactions(habitId string) ([]Reaction, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionView); err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetReactions(habitId)
+}
@@ -0,0 +1,69 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestReactions(t *testing.T) {
+	newApps := func(t *testing.T) (app *habit_share.App, friendApp *habit_share.App, habitId string, activityId string) {
+		app, friendApp, habitId = newFriendApps(t)
+		today, _ := app.HabitToday(habitId)
+		activityId, err := app.CreateActivity(habitId, today, habit_share.ActivitySuccess, 0)
+		if err != nil {
+			t.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+		return app, friendApp, habitId, activityId
+	}
+
+	t.Run("should only let people the habit is shared with react", func(t *testing.T) {
+		app, friendApp, habitId, activityId := newApps(t)
+
+		if err := friendApp.React(habitId, activityId, "🎉"); err != habit_share.PermissionDeniedError {
+			t.Error("expected PermissionDeniedError got:", err)
+		}
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if err := friendApp.React(habitId, activityId, "🎉"); err != nil {
+			t.Fatal("React returned error unexpectedly:", err)
+		}
+
+		reactions, err := app.GetReactions(habitId)
+		if err != nil {
+			t.Fatal("GetReactions returned error unexpectedly:", err)
+		}
+		if len(reactions) != 1 || reactions[0].User != "friend" || reactions[0].ActivityId != activityId {
+			t.Errorf("expected friend's reaction got %v", reactions)
+		}
+	})
+
+	t.Run("should replace a user's reaction", func(t *testing.T) {
+		app, _, habitId, activityId := newApps(t)
+
+		for _, emoji := range []string{"👍", "CHEER"} {
+			if err := app.React(habitId, activityId, emoji); err != nil {
+				t.Fatal("React returned error unexpectedly:", err)
+			}
+		}
+		if reactions, _ := app.GetReactions(habitId); len(reactions) != 1 || reactions[0].Emoji != "CHEER" {
+			t.Errorf("expected the latest reaction only got %v", reactions)
+		}
+
+		if err := app.Unreact(habitId, activityId); err != nil {
+			t.Fatal("Unreact returned error unexpectedly:", err)
+		}
+		if reactions, _ := app.GetReactions(habitId); len(reactions) != 0 {
+			t.Errorf("expected no reactions got %v", reactions)
+		}
+	})
+
+	t.Run("should reject reactions to missing activities", func(t *testing.T) {
+		app, _, habitId, _ := newApps(t)
+
+		if err := app.React(habitId, "missing", "👍"); err != habit_share.ActivityNotFoundError {
+			t.Error("expected ActivityNotFoundError got:", err)
+		}
+	})
+}
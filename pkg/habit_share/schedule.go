@@ -0,0 +1,54 @@
+package habit_share
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Schedule describes which days a habit is due on.
+// The zero value is the original behaviour of "Frequency times per week" where
+// any day of the week counts towards the goal.
+type Schedule struct {
+	// Days of the week the habit is due on. JSON encodes these as 0 (Sunday)
+	// through 6 (Saturday) following time.Weekday.
+	Weekdays []time.Weekday
+}
+
+// NewSchedule validates the weekdays and returns them sorted without duplicates
+func NewSchedule(weekdays []time.Weekday) (Schedule, error) {
+	seen := make(map[time.Weekday]struct{}, len(weekdays))
+	sorted := make([]time.Weekday, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return Schedule{}, &InputError{StringToParse: fmt.Sprint(int(weekday))}
+		}
+		if _, ok := seen[weekday]; ok {
+			continue
+		}
+		seen[weekday] = struct{}{}
+		sorted = append(sorted, weekday)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return Schedule{Weekdays: sorted}, nil
+}
+
+// IsWeekly is true when the habit isn't tied to particular days
+func (s Schedule) IsWeekly() bool {
+	return len(s.Weekdays) == 0
+}
+
+// IsDue reports whether missing the habit on day would break a streak.
+// Weekly schedules are never due on a particular day.
+func (s Schedule) IsDue(day time.Time) bool {
+	for _, weekday := range s.Weekdays {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,257 @@
+package habit_share
+
+import (
+	"sync"
+	"time"
+)
+
+// IncrementalScoringStrategy can work out how a score changes without going
+// through the habit's history again
+type IncrementalScoringStrategy interface {
+	ScoringStrategy
+	// Update is how the score changes when the status logged on day goes from
+	// previous to updated. An empty status is nothing logged. ok is false when
+	// the score has to be calculated from scratch.
+	Update(habit Habit, day time.Time, today time.Time, previous string, updated string) (delta int, ok bool)
+}
+
+func (StreakScore) Update(habit Habit, day time.Time, today time.Time, previous string, updated string) (int, bool) {
+	// today is part of the streak no matter what so only its successes change.
+	// Any other day could start or end a streak.
+	if !sameDay(day, today) {
+		return 0, false
+	}
+	return successDelta(previous, updated), true
+}
+
+func (CountScore) Update(habit Habit, day time.Time, today time.Time, previous string, updated string) (int, bool) {
+	return successDelta(previous, updated), true
+}
+
+func successDelta(previous string, updated string) int {
+	delta := 0
+	if previous == ActivitySuccess {
+		delta--
+	}
+	if updated == ActivitySuccess {
+		delta++
+	}
+	return delta
+}
+
+func sameDay(a time.Time, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// ScoreCache keeps scores between requests so a habit's history is only gone
+// through once a day. Scores are only good for the day they were calculated on
+// as periods end and strengths decay with the days. Changes the cache can't
+// work out in place drop the score so it's calculated again.
+// A nil ScoreCache caches nothing.
+type ScoreCache struct {
+	mu     sync.Mutex
+	scores map[string]cachedScore
+	// bumped on every change so scores calculated from older data aren't kept
+	versions map[string]int
+	// changes being written, scores calculated meanwhile may or may not include
+	// them so aren't kept
+	pending map[string]int
+}
+
+type cachedScore struct {
+	owner string
+	today time.Time
+	score int
+}
+
+func NewScoreCache() *ScoreCache {
+	return &ScoreCache{
+		scores:   make(map[string]cachedScore),
+		versions: make(map[string]int),
+		pending:  make(map[string]int),
+	}
+}
+
+// get returns the score if one was cached today along with the version to
+// pass to set
+func (c *ScoreCache) get(habitId string, today time.Time) (score int, ok bool, version int) {
+	if c == nil {
+		return 0, false, 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.scores[habitId]
+	if !ok || !cached.today.Equal(today) {
+		return 0, false, c.versions[habitId]
+	}
+	return cached.score, true, c.versions[habitId]
+}
+
+// set caches the score unless the habit changed since version was read
+func (c *ScoreCache) set(habitId string, owner string, today time.Time, score int, version int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.versions[habitId] != version || c.pending[habitId] > 0 {
+		return
+	}
+	c.scores[habitId] = cachedScore{owner: owner, today: today, score: score}
+}
+
+// begin marks the start of a change to the habit's activities. It must be
+// followed by end once the change is written.
+func (c *ScoreCache) begin(habitId string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending[habitId]++
+}
+
+func (c *ScoreCache) end(habitId string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending[habitId]--
+	if c.pending[habitId] <= 0 {
+		delete(c.pending, habitId)
+	}
+}
+
+func (c *ScoreCache) has(habitId string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.scores[habitId]
+	return ok
+}
+
+// add changes the cached score by delta if it was calculated today
+func (c *ScoreCache) add(habitId string, today time.Time, delta int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[habitId]++
+	cached, ok := c.scores[habitId]
+	if !ok {
+		return
+	}
+	if !cached.today.Equal(today) {
+		delete(c.scores, habitId)
+		return
+	}
+	cached.score += delta
+	c.scores[habitId] = cached
+}
+
+// Invalidate drops the score of the habit
+func (c *ScoreCache) Invalidate(habitId string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[habitId]++
+	delete(c.scores, habitId)
+}
+
+// InvalidateOwner drops the scores of every habit owner has
+func (c *ScoreCache) InvalidateOwner(owner string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for habitId, cached := range c.scores {
+		if cached.owner == owner {
+			c.versions[habitId]++
+			delete(c.scores, habitId)
+		}
+	}
+}
+
+// scoreUpdate is what was logged on a day before it changed
+type scoreUpdate struct {
+	habit      Habit
+	day        time.Time
+	previousId string
+	previous   string
+	// false drops the cached score instead of updating it
+	ok bool
+}
+
+// beforeScoreChange looks up what was logged on day so the cached score can be
+// updated once it changes. It must be followed by afterScoreChange.
+func (a *App) beforeScoreChange(habit Habit, day time.Time) scoreUpdate {
+	a.Scores.begin(habit.Id)
+	update := scoreUpdate{habit: habit, day: day}
+	// a member logging doesn't say what the day combines to
+	if !a.Scores.has(habit.Id) || habit.IsGroup() {
+		return update
+	}
+
+	activities, _, err := a.Db.GetActivities(
+		habit.Id,
+		Time{Time: day},
+		Time{Time: day.AddDate(0, 0, 1)},
+		1,
+	)
+	if err != nil {
+		return update
+	}
+	if len(activities) > 0 {
+		update.previousId = activities[0].Id
+		update.previous = activities[0].Status
+	}
+	update.ok = true
+	return update
+}
+
+// afterScoreChange updates the cached score now updated is logged on the day
+func (a *App) afterScoreChange(update scoreUpdate, updated string) {
+	defer a.Scores.end(update.habit.Id)
+	if !update.ok {
+		a.Scores.Invalidate(update.habit.Id)
+		return
+	}
+
+	strategy, err := ScoringFor(update.habit.Scoring)
+	if err != nil {
+		a.Scores.Invalidate(update.habit.Id)
+		return
+	}
+	incremental, ok := strategy.(IncrementalScoringStrategy)
+	if !ok {
+		a.Scores.Invalidate(update.habit.Id)
+		return
+	}
+	today, err := a.todayFor(update.habit.Owner)
+	if err != nil {
+		a.Scores.Invalidate(update.habit.Id)
+		return
+	}
+
+	delta, ok := incremental.Update(update.habit, update.day, today.Time, update.previous, updated)
+	if !ok {
+		a.Scores.Invalidate(update.habit.Id)
+		return
+	}
+	a.Scores.add(update.habit.Id, today.Time, delta)
+}
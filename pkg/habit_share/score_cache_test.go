@@ -0,0 +1,192 @@
+package habit_share_test
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share_file"
+)
+
+type testAuth struct{}
+
+func (testAuth) GetCurrentUser() (string, error) {
+	return "testUser", nil
+}
+
+// newTestDb returns an empty in-memory database
+func newTestDb() *habit_share_file.HabitShareFile {
+	return &habit_share_file.HabitShareFile{
+		Users:  map[string]habit_share_file.User{},
+		Habits: map[string]habit_share_file.HabitJson{},
+	}
+}
+
+// newFriendApps returns apps for testUser and friend over the same in-memory
+// database along with a habit of testUser's
+func newFriendApps(tb testing.TB) (app *habit_share.App, friendApp *habit_share.App, habitId string) {
+	db := newTestDb()
+	app = &habit_share.App{Db: db, Auth: testAuth{}}
+	friendApp = &habit_share.App{Db: db, Auth: otherAuth{}}
+
+	habitId, err := app.CreateHabit("mine", 3, habit_share.Period{})
+	if err != nil {
+		tb.Fatal("CreateHabit returned error unexpectedly:", err)
+	}
+	return app, friendApp, habitId
+}
+
+// newTestApps returns apps with and without a ScoreCache over the same
+// in-memory database along with a habit logged every day for days
+func newTestApps(tb testing.TB, days int) (cached *habit_share.App, uncached *habit_share.App, habitId string) {
+	db := newTestDb()
+	cached = &habit_share.App{Db: db, Auth: testAuth{}, Scores: habit_share.NewScoreCache()}
+	uncached = &habit_share.App{Db: db, Auth: testAuth{}}
+
+	habitId, err := uncached.CreateHabit("test habit", 3, habit_share.Period{})
+	if err != nil {
+		tb.Fatal("CreateHabit returned error unexpectedly:", err)
+	}
+	today, err := uncached.Today()
+	if err != nil {
+		tb.Fatal("Today returned error unexpectedly:", err)
+	}
+	for i := days; i > 0; i-- {
+		logged := habit_share.Time{Time: today.AddDate(0, 0, -i)}
+		if _, err := uncached.CreateActivity(habitId, logged, habit_share.ActivitySuccess, 0); err != nil {
+			tb.Fatal("CreateActivity returned error unexpectedly:", err)
+		}
+	}
+
+	return cached, uncached, habitId
+}
+
+func scoreOf(tb testing.TB, app *habit_share.App, habitId string) int {
+	score, err := app.GetScore(habitId)
+	if err != nil {
+		tb.Fatal("GetScore returned error unexpectedly:", err)
+	}
+	return score
+}
+
+func TestScoreCache(t *testing.T) {
+	t.Run("should keep up with activities logged today", func(t *testing.T) {
+		cached, uncached, habitId := newTestApps(t, 30)
+		today, _ := cached.Today()
+		scoreOf(t, cached, habitId)
+
+		for _, status := range []string{
+			habit_share.ActivitySuccess,
+			habit_share.ActivityNotDone,
+			habit_share.ActivitySuccess,
+		} {
+			activityId, err := cached.CreateActivity(habitId, today, status, 0)
+			if err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+
+			if got, want := scoreOf(t, cached, habitId), scoreOf(t, uncached, habitId); got != want {
+				t.Fatal("expected cached score", want, "got", got, "after logging", status)
+			}
+
+			if status == habit_share.ActivitySuccess {
+				continue
+			}
+			if err := cached.DeleteActivity(habitId, activityId); err != nil {
+				t.Fatal("DeleteActivity returned error unexpectedly:", err)
+			}
+			if got, want := scoreOf(t, cached, habitId), scoreOf(t, uncached, habitId); got != want {
+				t.Fatal("expected cached score", want, "got", got, "after deleting")
+			}
+		}
+	})
+
+	t.Run("should recalculate after past activities change", func(t *testing.T) {
+		cached, uncached, habitId := newTestApps(t, 30)
+		today, _ := cached.Today()
+		scoreOf(t, cached, habitId)
+
+		// breaks the streak
+		for i := 7; i < 14; i++ {
+			logged := habit_share.Time{Time: today.AddDate(0, 0, -i)}
+			if _, err := cached.CreateActivity(habitId, logged, habit_share.ActivityNotDone, 0); err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+		}
+
+		if got, want := scoreOf(t, cached, habitId), scoreOf(t, uncached, habitId); got != want {
+			t.Fatal("expected cached score", want, "got", got)
+		}
+	})
+
+	t.Run("should recalculate after the frequency changes", func(t *testing.T) {
+		cached, uncached, habitId := newTestApps(t, 30)
+		before := scoreOf(t, cached, habitId)
+
+		if err := cached.ChangeScoring(habitId, habit_share.ScoringStrength); err != nil {
+			t.Fatal("ChangeScoring returned error unexpectedly:", err)
+		}
+		if got, want := scoreOf(t, cached, habitId), scoreOf(t, uncached, habitId); got != want || got == before {
+			t.Fatal("expected cached score", want, "got", got)
+		}
+
+		if err := cached.ChangeFrequency(habitId, 7); err != nil {
+			t.Fatal("ChangeFrequency returned error unexpectedly:", err)
+		}
+		if got, want := scoreOf(t, cached, habitId), scoreOf(t, uncached, habitId); got != want {
+			t.Fatal("expected cached score", want, "got", got)
+		}
+	})
+}
+
+// three years of logging every day
+const benchmarkDays = 3 * 365
+
+func BenchmarkGetScore(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		_, uncached, habitId := newTestApps(b, benchmarkDays)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			scoreOf(b, uncached, habitId)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		cached, _, habitId := newTestApps(b, benchmarkDays)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			scoreOf(b, cached, habitId)
+		}
+	})
+}
+
+func BenchmarkLogThenGetScore(b *testing.B) {
+	statuses := []string{habit_share.ActivitySuccess, habit_share.ActivityNotDone}
+
+	b.Run("uncached", func(b *testing.B) {
+		_, uncached, habitId := newTestApps(b, benchmarkDays)
+		today, _ := uncached.Today()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := uncached.CreateActivity(habitId, today, statuses[i%2], 0); err != nil {
+				b.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+			scoreOf(b, uncached, habitId)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		cached, _, habitId := newTestApps(b, benchmarkDays)
+		today, _ := cached.Today()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := cached.CreateActivity(habitId, today, statuses[i%2], 0); err != nil {
+				b.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+			scoreOf(b, cached, habitId)
+		}
+	})
+}
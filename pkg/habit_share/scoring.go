@@ -0,0 +1,265 @@
+package habit_share
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// successes since the last period that didn't meet Frequency
+	ScoringStreak = "STREAK"
+	// percentage that rises while the habit is kept and decays while it isn't
+	ScoringStrength = "STRENGTH"
+	// successes ever
+	ScoringCount = "COUNT"
+)
+
+// ScoringStrategy turns the history of a habit into a single number
+type ScoringStrategy interface {
+	// activities are sorted oldest first and may include days after today.
+	// today is the date it currently is for the owner of the habit.
+	Score(habit Habit, activities []Activity, vacations []Vacation, today time.Time) int
+}
+
+var scoringStrategies = map[string]ScoringStrategy{
+	ScoringStreak:   StreakScore{},
+	ScoringStrength: StrengthScore{},
+	ScoringCount:    CountScore{},
+}
+
+// ScoringFor looks up a strategy by name. An empty name is ScoringStreak.
+func ScoringFor(name string) (ScoringStrategy, error) {
+	if name == "" {
+		name = ScoringStreak
+	}
+	strategy, ok := scoringStrategies[name]
+	if !ok {
+		return nil, &InputError{StringToParse: name}
+	}
+	return strategy, nil
+}
+
+// far enough in the future to include every activity
+var endOfTime = Time{Time: time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)}
+
+// GetScore scores the habit with its strategy
+func (a *App) GetScore(habitId string) (int, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := a.habitMemberCheck(habit); err != nil {
+		if err := a.habitSharedCheck(habit); err != nil {
+			// neither owned nor shared
+			return 0, err
+		}
+		// not owner but shared
+	}
+
+	return a.score(habit)
+}
+
+// score is GetScore without checking who is asking
+func (a *App) score(habit Habit) (int, error) {
+	habitId := habit.Id
+	strategy, err := ScoringFor(habit.Scoring)
+	if err != nil {
+		return 0, err
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return 0, err
+	}
+	score, ok, version := a.Scores.get(habitId, today.Time)
+	if ok {
+		return score, nil
+	}
+
+	vacations, err := a.Db.GetVacations(habit.Owner)
+	if err != nil {
+		return 0, err
+	}
+	activities, _, err := a.Db.GetActivities(habitId, Time{}, endOfTime, math.MaxInt32)
+	if err != nil {
+		return 0, err
+	}
+
+	activities = habit.CombineActivities(activities)
+
+	score = strategy.Score(habit, activities, vacations, today.Time)
+	a.Scores.set(habitId, habit.Owner, today.Time, score, version)
+	return score, nil
+}
+
+func (a *App) ChangeScoring(id string, scoring string) error {
+	habit, err := a.Db.GetHabit(id)
+	if err != nil {
+		return err
+	}
+	if err := a.habitPermissionCheck(habit, PermissionEdit); err != nil {
+		return err
+	}
+
+	if _, err := ScoringFor(scoring); err != nil {
+		return err
+	}
+	habit.Scoring = scoring
+	err = a.setHabit(id, habit)
+	a.Scores.Invalidate(id)
+	return err
+}
+
+// StreakScore counts successes back to the last period that didn't meet
+// Frequency. The current period is always part of the streak as there is
+// still time to meet it and so is the first as the habit may have been started
+// part way through it.
+type StreakScore struct{}
+
+func (StreakScore) Score(habit Habit, activities []Activity, vacations []Vacation, today time.Time) int {
+	// today is already a date but we can't be sure it's in UTC
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	totalScore := 0
+	// periods starting before this have not been counted yet
+	until := endOfTime.Time
+	if !habit.Schedule.IsWeekly() {
+		var broken bool
+		totalScore, until, broken = scheduledStreak(habit, activities, vacations, today)
+		if broken {
+			return totalScore
+		}
+	}
+
+	periods := EvaluatePeriods(habit, activities, vacations, today)
+	for i := len(periods) - 1; i >= 0; i-- {
+		// already counted day by day
+		if periods[i].End.After(until) {
+			continue
+		}
+		if periods[i].BreaksStreak(i == 0) {
+			return totalScore
+		}
+		totalScore += periods[i].Successes
+	}
+
+	return totalScore
+}
+
+// scheduledStreak counts successes back to the first scheduled day that was
+// missed. Days the habit isn't scheduled for, were excused or the owner was on
+// vacation can't break the streak and today is never missed as there is still
+// time left to do it. It stops early at days before the habit had a schedule
+// returning the earliest day it counted.
+func scheduledStreak(habit Habit, activities []Activity, vacations []Vacation, today time.Time) (totalScore int, until time.Time, broken bool) {
+	index := len(activities) - 1
+	day := today
+	for ; index >= 0; day = day.AddDate(0, 0, -1) {
+		schedule := habit.At(day).Schedule
+		if schedule.IsWeekly() {
+			break
+		}
+
+		done := false
+		// activities logged on or after this day (future activities land on today)
+		for ; index >= 0 && !activities[index].Logged.Before(day); index-- {
+			switch activities[index].Status {
+			case ActivitySuccess:
+				totalScore++
+				done = true
+			case ActivityMinimum, ActivityExcused:
+				done = true
+			}
+		}
+
+		if !done && day.Before(today) && schedule.IsDue(day) &&
+			!OnVacation(vacations, day, day.AddDate(0, 0, 1)) {
+			return totalScore, day, true
+		}
+	}
+
+	return totalScore, day.AddDate(0, 0, 1), false
+}
+
+// StrengthScore is a percentage in the style of Loop Habit Tracker. Each day
+// moves the score towards how much of the Frequency was done over the last
+// Period. Missing a day after months of keeping a habit only dents the score
+// where a streak would start over. Days on vacation leave the score as it is.
+type StrengthScore struct{}
+
+func (StrengthScore) Score(habit Habit, activities []Activity, vacations []Vacation, today time.Time) int {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if len(activities) == 0 {
+		return 0
+	}
+
+	first := activities[0].Logged.Time
+	first = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	if first.After(today) {
+		return 0
+	}
+	// doneBefore[i] is the number of days done before the i-th day since first
+	doneBefore := []int{0}
+	index := 0
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		nextDay := day.AddDate(0, 0, 1)
+		done := 0
+		for ; index < len(activities) && activities[index].Logged.Before(nextDay); index++ {
+			switch activities[index].Status {
+			case ActivitySuccess, ActivityMinimum, ActivityExcused:
+				done = 1
+			}
+		}
+		doneBefore = append(doneBefore, doneBefore[len(doneBefore)-1]+done)
+	}
+	days := len(doneBefore) - 1
+	// there is still time to do it today
+	if doneBefore[days] == doneBefore[days-1] {
+		days--
+	}
+
+	score := 0.0
+	for i := 0; i < days; i++ {
+		day := first.AddDate(0, 0, i)
+		if OnVacation(vacations, day, day.AddDate(0, 0, 1)) {
+			continue
+		}
+
+		scheduled := habit.At(day)
+		// assumes once a day at most like MaxFrequency
+		periodDays := scheduled.Period.MaxFrequency()
+		frequency := scheduled.Frequency
+		if !scheduled.Schedule.IsWeekly() {
+			periodDays = 7
+			frequency = len(scheduled.Schedule.Weekdays)
+		}
+		if frequency < 1 {
+			continue
+		}
+		// frequent habits move faster, the score halves in 13 days for a daily habit
+		multiplier := math.Pow(0.5, math.Sqrt(float64(frequency)/float64(periodDays))/13)
+
+		windowStart := i + 1 - periodDays
+		if windowStart < 0 {
+			windowStart = 0
+		}
+		completed := math.Min(1, float64(doneBefore[i+1]-doneBefore[windowStart])/float64(frequency))
+		score = score*multiplier + completed*(1-multiplier)
+	}
+
+	return int(math.Round(score * 100))
+}
+
+// CountScore is every success ever logged
+type CountScore struct{}
+
+func (CountScore) Score(habit Habit, activities []Activity, vacations []Vacation, today time.Time) int {
+	count := 0
+	for _, activity := range activities {
+		if activity.Status == ActivitySuccess {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,182 @@
+package habit_share
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoring(t *testing.T) {
+	// a Wednesday
+	today := time.Date(2022, time.May, 25, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should default to the streak score", func(t *testing.T) {
+		strategy, err := ScoringFor("")
+		if err != nil {
+			t.Fatal("ScoringFor returned error unexpectedly:", err)
+		}
+		if _, ok := strategy.(StreakScore); !ok {
+			t.Error("expected StreakScore got", strategy)
+		}
+
+		if _, err := ScoringFor("BEST"); err == nil {
+			t.Error("expected unknown strategy to be rejected")
+		}
+	})
+
+	t.Run("should calcuate streak is 0", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+
+		score := StreakScore{}.Score(habit, []Activity{}, nil, today)
+
+		if score != 0 {
+			t.Fatal("Score not calculated properly expected 0 got:", score)
+		}
+	})
+
+	t.Run("should calcuate streak is 1", func(t *testing.T) {
+		habit := Habit{Frequency: 7}
+		activities := testActivities([]string{"2001-01-01", "2022-05-25"}, ActivitySuccess)
+
+		score := StreakScore{}.Score(habit, activities, nil, today)
+
+		if score != 1 {
+			t.Fatal("Score not calculated properly expected 1 got:", score)
+		}
+	})
+
+	t.Run("should not break streak on unscheduled days", func(t *testing.T) {
+		habit := Habit{Frequency: 1, Schedule: Schedule{Weekdays: []time.Weekday{time.Wednesday}}}
+		activities := testActivities([]string{"2022-05-23"}, ActivitySuccess)
+
+		score := StreakScore{}.Score(habit, activities, nil, today)
+
+		if score != 1 {
+			t.Fatal("Score not calculated properly expected 1 got:", score)
+		}
+	})
+
+	t.Run("should break streak on missed scheduled day", func(t *testing.T) {
+		habit := Habit{Frequency: 1, Schedule: Schedule{Weekdays: []time.Weekday{time.Tuesday}}}
+		activities := testActivities([]string{"2022-05-23"}, ActivitySuccess)
+
+		score := StreakScore{}.Score(habit, activities, nil, today)
+
+		if score != 0 {
+			t.Fatal("Score not calculated properly expected 0 got:", score)
+		}
+	})
+
+	t.Run("should count streak over monthly periods", func(t *testing.T) {
+		habit := Habit{Frequency: 1, Period: Period{Unit: PeriodMonth, Length: 1}}
+		activities := testActivities([]string{
+			"2022-02-01", // skipped month after this one breaks the streak
+			"2022-04-01",
+			"2022-04-11",
+			"2022-05-01",
+		}, ActivitySuccess)
+
+		score := StreakScore{}.Score(habit, activities, nil, today)
+
+		if score != 3 {
+			t.Fatal("Score not calculated properly expected 3 got:", score)
+		}
+	})
+
+	t.Run("should lower requirement of period with excused days", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+		// the week before last is only reached if last week met frequency
+		activities := testActivities([]string{
+			"2022-05-15",
+			"2022-05-16",
+			"2022-05-17",
+			"2022-05-18",
+		}, ActivitySuccess)
+		activities[2].Status = ActivityExcused
+
+		score := StreakScore{}.Score(habit, activities, nil, today)
+
+		if score != 3 {
+			t.Fatal("Score not calculated properly expected 3 got:", score)
+		}
+	})
+
+	t.Run("should not break streak during vacation", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+		activities := testActivities([]string{"2022-05-09", "2022-05-10", "2022-05-11"}, ActivitySuccess)
+		start, _ := time.Parse(DateFormat, "2022-05-18")
+		end, _ := time.Parse(DateFormat, "2022-05-20")
+		vacations := []Vacation{{Start: Time{Time: start}, End: Time{Time: end}}}
+
+		score := StreakScore{}.Score(habit, activities, vacations, today)
+
+		if score != 3 {
+			t.Fatal("Score not calculated properly expected 3 got:", score)
+		}
+	})
+
+	t.Run("should build and decay strength", func(t *testing.T) {
+		habit := Habit{Frequency: 1, Period: Period{Unit: PeriodDay, Length: 1}}
+		dates := make([]string, 0)
+		for day := today.AddDate(-1, 0, 0); !day.After(today); day = day.AddDate(0, 0, 1) {
+			dates = append(dates, day.Format(DateFormat))
+		}
+		activities := testActivities(dates, ActivitySuccess)
+
+		kept := StrengthScore{}.Score(habit, activities, nil, today)
+		if kept != 100 {
+			t.Error("expected a year of every day to be 100 got", kept)
+		}
+
+		missed := StrengthScore{}.Score(habit, activities[:len(activities)-3], nil, today)
+		if missed >= kept || missed < 80 {
+			t.Error("expected a few missed days to dent the strength got", missed)
+		}
+	})
+
+	t.Run("should not decay strength during vacation", func(t *testing.T) {
+		habit := Habit{Frequency: 1, Period: Period{Unit: PeriodDay, Length: 1}}
+		activities := testActivities([]string{"2022-05-01", "2022-05-02", "2022-05-03"}, ActivitySuccess)
+		start, _ := time.Parse(DateFormat, "2022-05-04")
+		end, _ := time.Parse(DateFormat, "2022-05-30")
+		vacations := []Vacation{{Start: Time{Time: start}, End: Time{Time: end}}}
+
+		before := StrengthScore{}.Score(habit, activities, vacations, time.Date(2022, time.May, 3, 0, 0, 0, 0, time.UTC))
+		after := StrengthScore{}.Score(habit, activities, vacations, today)
+
+		if before != after {
+			t.Error("expected strength to stay at", before, "got", after)
+		}
+	})
+
+	t.Run("should count every success", func(t *testing.T) {
+		habit := Habit{Frequency: 3}
+		activities := testActivities([]string{"2001-01-01", "2022-05-16", "2022-05-17"}, ActivitySuccess)
+		activities[2].Status = ActivityMinimum
+
+		score := CountScore{}.Score(habit, activities, nil, today)
+
+		if score != 2 {
+			t.Fatal("Score not calculated properly expected 2 got:", score)
+		}
+	})
+}
+
+func TestScoreCacheRollover(t *testing.T) {
+	today := time.Date(2022, time.May, 29, 0, 0, 0, 0, time.UTC)
+	cache := NewScoreCache()
+	_, _, version := cache.get("habitId", today)
+	cache.set("habitId", "owner", today, 5, version)
+
+	if score, ok, _ := cache.get("habitId", today); !ok || score != 5 {
+		t.Error("expected score of 5 to be cached got", score, ok)
+	}
+	// a Monday, the week has rolled over
+	if _, ok, _ := cache.get("habitId", today.AddDate(0, 0, 1)); ok {
+		t.Error("expected score from yesterday to not be used")
+	}
+
+	cache.add("habitId", today.AddDate(0, 0, 1), 1)
+	if _, ok, _ := cache.get("habitId", today); ok {
+		t.Error("expected score from yesterday to be dropped when updated today")
+	}
+}
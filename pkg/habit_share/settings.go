@@ -0,0 +1,88 @@
+package habit_share
+
+import (
+	"fmt"
+	"time"
+)
+
+// UserSettings decide when a user's day starts. The zero value is midnight UTC.
+type UserSettings struct {
+	// IANA name e.g. Australia/Brisbane
+	Timezone string
+	// Hour of the day (0-23) the next day begins
+	DayStartHour int
+}
+
+// Location falls back to UTC if Timezone isn't usable
+func (s UserSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Today is the day it currently is for the user
+func (s UserSettings) Today() Time {
+	return LogicalDay(time.Now(), s.Location(), s.DayStartHour)
+}
+
+func (a *App) GetMySettings() (UserSettings, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return UserSettings{}, err
+	}
+
+	return a.Db.GetUserSettings(user)
+}
+
+func (a *App) ChangeSettings(timezone string, dayStartHour int) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return &InputError{StringToParse: timezone}
+	}
+	if dayStartHour < 0 || dayStartHour > 23 {
+		return &InputError{StringToParse: fmt.Sprint(dayStartHour)}
+	}
+
+	err = a.Db.SetUserSettings(user, UserSettings{Timezone: timezone, DayStartHour: dayStartHour})
+	a.Scores.InvalidateOwner(user)
+	return err
+}
+
+// Today is the day it currently is for the current user
+func (a *App) Today() (Time, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return Time{}, err
+	}
+
+	return a.todayFor(user)
+}
+
+// HabitToday is the day it currently is for the owner of the habit. Streaks
+// and activities belong to the owner so their day is the one that counts.
+func (a *App) HabitToday(habitId string) (Time, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return Time{}, err
+	}
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+		return Time{}, HabitNotFoundError
+	}
+
+	return a.todayFor(habit.Owner)
+}
+
+func (a *App) todayFor(user string) (Time, error) {
+	settings, err := a.Db.GetUserSettings(user)
+	if err != nil {
+		return Time{}, err
+	}
+
+	return settings.Today(), nil
+}
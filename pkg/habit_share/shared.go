@@ -0,0 +1,116 @@
+package habit_share
+
+import (
+	"math"
+	"time"
+)
+
+// sharedHabits returns the habits shared with the current user that are either
+// muted or not, leaving out expired shares and those whose owner blocked the
+// user
+func (a *App) sharedHabits(limit int, muted bool) ([]Habit, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	// everything is fetched as the limit applies to what's left after filtering
+	habits, err := a.Db.GetSharedHabits(user, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	mutedIds, err := a.Db.GetMutedHabits(user)
+	if err != nil {
+		return nil, err
+	}
+	mutedSet := make(map[string]struct{}, len(mutedIds))
+	for _, id := range mutedIds {
+		mutedSet[id] = struct{}{}
+	}
+
+	// the database's slice is left alone in case it's in memory
+	now := time.Now()
+	visible := make([]Habit, 0)
+	for _, habit := range habits {
+		if len(visible) >= limit {
+			break
+		}
+		if _, ok := mutedSet[habit.Id]; ok != muted {
+			continue
+		}
+		// expired shares may not have been swept yet
+		if habit.ShareExpired(user, now) {
+			continue
+		}
+		if blocked, err := a.blocked(habit.Owner, user); err != nil {
+			return nil, err
+		} else if !blocked {
+			visible = append(visible, habit)
+		}
+	}
+
+	return visible, nil
+}
+
+// GetMutedHabits returns the habits shared with the current user that they
+// muted
+func (a *App) GetMutedHabits(limit int) ([]Habit, error) {
+	return a.sharedHabits(limit, true)
+}
+
+// recipientCheck ensures the habit is shared with the current user, returning
+// who they are
+func (a *App) recipientCheck(habitId string) (string, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := habit.SharedWith[user]; !ok {
+		return "", HabitNotFoundError
+	}
+
+	return user, nil
+}
+
+// LeaveHabit stops the habit being shared with the current user
+func (a *App) LeaveHabit(habitId string) error {
+	user, err := a.recipientCheck(habitId)
+	if err != nil {
+		return err
+	}
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Db.UnShareHabit(habitId, user); err != nil {
+		return err
+	}
+	a.publish(habit, LiveHabitChanged, "", user)
+	return nil
+}
+
+// MuteHabit hides the habit shared with the current user from their shared
+// habits without ending the share
+func (a *App) MuteHabit(habitId string) error {
+	user, err := a.recipientCheck(habitId)
+	if err != nil {
+		return err
+	}
+
+	return a.Db.MuteHabit(user, habitId)
+}
+
+func (a *App) UnmuteHabit(habitId string) error {
+	user, err := a.recipientCheck(habitId)
+	if err != nil {
+		return err
+	}
+
+	return a.Db.UnmuteHabit(user, habitId)
+}
@@ -0,0 +1,102 @@
+package habit_share_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestSharedHabits(t *testing.T) {
+	newApps := func(t *testing.T) (app *habit_share.App, friendApp *habit_share.App, habitId string) {
+		app, friendApp, habitId = newFriendApps(t)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		return app, friendApp, habitId
+	}
+
+	t.Run("should let the recipient leave", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t)
+
+		if err := friendApp.LeaveHabit(habitId); err != nil {
+			t.Fatal("LeaveHabit returned error unexpectedly:", err)
+		}
+		if habits, _ := friendApp.GetSharedHabits(10); len(habits) != 0 {
+			t.Errorf("expected no shared habits got %v", habits)
+		}
+		if sharedWith, _ := app.GetSharedWith(habitId); len(sharedWith) != 0 {
+			t.Errorf("expected habit to be shared with no one got %v", sharedWith)
+		}
+		if err := friendApp.LeaveHabit(habitId); err != habit_share.HabitNotFoundError {
+			t.Error("expected HabitNotFoundError got:", err)
+		}
+	})
+
+	t.Run("should hide muted habits until unmuted", func(t *testing.T) {
+		_, friendApp, habitId := newApps(t)
+
+		if err := friendApp.MuteHabit(habitId); err != nil {
+			t.Fatal("MuteHabit returned error unexpectedly:", err)
+		}
+		if habits, _ := friendApp.GetSharedHabits(10); len(habits) != 0 {
+			t.Errorf("expected muted habit to be hidden got %v", habits)
+		}
+		if habits, _ := friendApp.GetMutedHabits(10); len(habits) != 1 || habits[0].Id != habitId {
+			t.Errorf("expected muted habit got %v", habits)
+		}
+		if _, err := friendApp.GetHabit(habitId); err != nil {
+			t.Error("expected muted habit to stay shared got:", err)
+		}
+
+		if err := friendApp.UnmuteHabit(habitId); err != nil {
+			t.Fatal("UnmuteHabit returned error unexpectedly:", err)
+		}
+		if habits, _ := friendApp.GetSharedHabits(10); len(habits) != 1 {
+			t.Errorf("expected unmuted habit to be listed got %v", habits)
+		}
+	})
+
+	t.Run("should fill the limit with habits that aren't muted", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t)
+		// sorts after "mine"
+		otherId, _ := app.CreateHabit("other", 3, habit_share.Period{})
+		if err := app.ShareHabit(otherId, "friend", habit_share.PermissionView, nil); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+		if err := friendApp.MuteHabit(habitId); err != nil {
+			t.Fatal("MuteHabit returned error unexpectedly:", err)
+		}
+
+		if habits, _ := friendApp.GetSharedHabits(1); len(habits) != 1 || habits[0].Id != otherId {
+			t.Errorf("expected only the other habit got %v", habits)
+		}
+	})
+
+	t.Run("should only let recipients mute", func(t *testing.T) {
+		app, _, habitId := newApps(t)
+
+		if err := app.MuteHabit(habitId); err != habit_share.HabitNotFoundError {
+			t.Error("expected HabitNotFoundError got:", err)
+		}
+	})
+	t.Run("should stop expired shares working", func(t *testing.T) {
+		app, friendApp, habitId := newApps(t)
+
+		expired := time.Now().Add(-time.Minute)
+		if err := app.ShareHabit(habitId, "friend", habit_share.PermissionView, &expired); err == nil {
+			t.Error("expected sharing with a past expiry to fail")
+		}
+		// stands in for a share that expired since it was made
+		if err := app.Db.ShareHabit(habitId, "friend", habit_share.PermissionView, &expired); err != nil {
+			t.Fatal("ShareHabit returned error unexpectedly:", err)
+		}
+
+		if habits, _ := friendApp.GetSharedHabits(10); len(habits) != 0 {
+			t.Errorf("expected expired share to be hidden got %v", habits)
+		}
+		if _, err := friendApp.GetHabit(habitId); err != habit_share.HabitNotFoundError {
+			t.Error("expected HabitNotFoundError got:", err)
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package habit_share
+
+import (
+	"math"
+	"time"
+)
+
+// PeriodResult is how the habit went over a single Period
+type PeriodResult struct {
+	Start Time
+	// the day after the period. Periods are usually the same length but can
+	// be cut short when the habit's Period was changed.
+	End Time
+	// Frequency in force at the Start
+	Frequency int
+	// Days done (SUCCESS or MINIMUM). For scheduled habits only the days the
+	// habit was due on count.
+	Count int
+	// SUCCESS only, every day counts even for scheduled habits
+	Successes int
+	// Count needed to meet the period after excuses and vacations
+	Required int
+	Excused  int
+	Met      bool
+	Vacation bool
+	// the current period isn't over so not meeting it doesn't break a streak
+	Current bool
+}
+
+// Neutral periods neither extend nor break a streak
+func (p PeriodResult) Neutral() bool {
+	return !p.Met && (p.Vacation || p.Current)
+}
+
+// BreaksStreak is whether missing the period ends a streak. The habit's first
+// period never does as the habit may have been started part way through it.
+func (p PeriodResult) BreaksStreak(first bool) bool {
+	return !p.Met && !p.Neutral() && !first
+}
+
+type CompletionRates struct {
+	Last30Days  float64
+	Last90Days  float64
+	Last365Days float64
+}
+
+// how many days of Periods GetStats returns, as far as the longest completion
+// rate
+const StatsDays = 365
+
+type Stats struct {
+	// in periods, usually weeks, over the habit's whole history
+	CurrentStreak   int
+	LongestStreak   int
+	CompletionRates CompletionRates
+	// oldest first ending with the current period, only those in the last
+	// StatsDays
+	Periods []PeriodResult
+}
+
+// GetStats summarises the habit
+func (a *App) GetStats(habitId string) (Stats, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return Stats{}, err
+	}
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+		return Stats{}, HabitNotFoundError
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return Stats{}, err
+	}
+	vacations, err := a.Db.GetVacations(habit.Owner)
+	if err != nil {
+		return Stats{}, err
+	}
+	// the longest streak could be from any time
+	activities, _, err := a.Db.GetActivities(habitId, Time{}, Time{Time: today.AddDate(0, 0, 1)}, math.MaxInt32)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	activities = habit.CombineActivities(activities)
+	return CalculateStats(EvaluatePeriods(habit, activities, vacations, today.Time), today.Time), nil
+}
+
+// evaluateWindow is EvaluatePeriods over only the periods ending after since.
+// Periods before the habit's first activity are left out as EvaluatePeriods
+// does, fromStart is whether that left the first period in.
+func (a *App) evaluateWindow(habit Habit, since time.Time, today time.Time) (periods []PeriodResult, fromStart bool, err error) {
+	vacations, err := a.Db.GetVacations(habit.Owner)
+	if err != nil {
+		return nil, false, err
+	}
+	tomorrow := Time{Time: today.AddDate(0, 0, 1)}
+	oldest, _, err := a.Db.GetActivities(habit.Id, Time{}, tomorrow, 1)
+	if err != nil {
+		return nil, false, err
+	}
+	first := today
+	if len(oldest) > 0 && oldest[0].Logged.Before(first) {
+		first = oldest[0].Logged.Time
+	}
+	fromStart = !first.Before(since)
+	if !fromStart {
+		first = since
+	}
+
+	activities, _, err := a.Db.GetActivities(
+		habit.Id,
+		Time{Time: habit.At(first).Period.Start(first)},
+		tomorrow,
+		math.MaxInt32,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	activities = habit.CombineActivities(activities)
+	return evaluatePeriodsFrom(habit, activities, vacations, first, today), fromStart, nil
+}
+
+// CalculateStats summarises periods as returned by EvaluatePeriods
+func CalculateStats(periods []PeriodResult, today time.Time) Stats {
+	stats := Stats{}
+	stats.CurrentStreak, stats.LongestStreak = streaks(periods, true)
+
+	since := today.AddDate(0, 0, -StatsDays)
+	first := len(periods)
+	for first > 0 && periods[first-1].End.After(since) {
+		first--
+	}
+	stats.Periods = periods[first:]
+
+	stats.CompletionRates = CompletionRates{
+		Last30Days:  completionRate(periods, today.AddDate(0, 0, -30)),
+		Last90Days:  completionRate(periods, today.AddDate(0, 0, -90)),
+		Last365Days: completionRate(periods, today.AddDate(0, 0, -365)),
+	}
+
+	return stats
+}
+
+// streaks counts the periods met in a row the same way StreakScore counts
+// successes. fromStart is whether periods starts with the habit's first.
+func streaks(periods []PeriodResult, fromStart bool) (current int, longest int) {
+	for i, period := range periods {
+		if period.BreaksStreak(fromStart && i == 0) {
+			current = 0
+			continue
+		}
+		if period.Neutral() {
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	return current, longest
+}
+
+// completionRate is the fraction of what was required that was done in the
+// finished periods ending after since
+func completionRate(periods []PeriodResult, since time.Time) float64 {
+	done := 0
+	required := 0
+	for i := len(periods) - 1; i >= 0 && periods[i].End.After(since); i-- {
+		if periods[i].Current || periods[i].Neutral() {
+			continue
+		}
+		required += periods[i].Required
+		if periods[i].Count < periods[i].Required {
+			done += periods[i].Count
+		} else {
+			done += periods[i].Required
+		}
+	}
+
+	if required == 0 {
+		return 0
+	}
+	return float64(done) / float64(required)
+}
+
+// EvaluatePeriods splits activities into the habit's periods and decides which
+// of them met the habit's frequency. Each period is judged by the Frequency,
+// Period and Schedule in force when it started. activities must be sorted
+// oldest first. The periods start from the first activity and end with the
+// current period.
+func EvaluatePeriods(habit Habit, activities []Activity, vacations []Vacation, today time.Time) []PeriodResult {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	first := today
+	if len(activities) > 0 && activities[0].Logged.Before(first) {
+		first = activities[0].Logged.Time
+	}
+
+	return evaluatePeriodsFrom(habit, activities, vacations, first, today)
+}
+
+// evaluatePeriodsFrom is EvaluatePeriods starting from the period first is in.
+// activities from before that period are left out.
+func evaluatePeriodsFrom(habit Habit, activities []Activity, vacations []Vacation, first time.Time, today time.Time) []PeriodResult {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	results := make([]PeriodResult, 0)
+	index := 0
+	periodStart := habit.At(first).Period.Start(first)
+	for index < len(activities) && activities[index].Logged.Before(periodStart) {
+		index++
+	}
+	for !periodStart.After(today) {
+		scheduled := habit.At(periodStart)
+		// a change of Period can leave periodStart part way through a period
+		periodEnd := scheduled.Period.Next(scheduled.Period.Start(periodStart))
+		result := PeriodResult{
+			Start:     Time{Time: periodStart},
+			End:       Time{Time: periodEnd},
+			Frequency: scheduled.Frequency,
+			Vacation:  OnVacation(vacations, periodStart, periodEnd),
+			Current:   today.Before(periodEnd),
+		}
+
+		if scheduled.Schedule.IsWeekly() {
+			for ; index < len(activities) && activities[index].Logged.Before(periodEnd); index++ {
+				switch activities[index].Status {
+				case ActivitySuccess:
+					result.Successes++
+					result.Count++
+				case ActivityMinimum:
+					result.Count++
+				case ActivityExcused:
+					result.Excused++
+				}
+			}
+			result.Required = scheduled.Frequency - result.Excused
+			if result.Required < 0 {
+				result.Required = 0
+			}
+		} else {
+			// only the days the habit is due on matter
+			for day := periodStart; day.Before(periodEnd); day = day.AddDate(0, 0, 1) {
+				nextDay := day.AddDate(0, 0, 1)
+				done := false
+				excused := false
+				for ; index < len(activities) && activities[index].Logged.Before(nextDay); index++ {
+					switch activities[index].Status {
+					case ActivitySuccess:
+						result.Successes++
+						done = true
+					case ActivityMinimum:
+						done = true
+					case ActivityExcused:
+						excused = true
+					}
+				}
+
+				if !scheduled.Schedule.IsDue(day) || OnVacation(vacations, day, nextDay) {
+					continue
+				}
+				if excused {
+					result.Excused++
+					continue
+				}
+				// there is still time to do it today
+				if !day.Before(today) && !done {
+					continue
+				}
+				result.Required++
+				if done {
+					result.Count++
+				}
+			}
+		}
+
+		result.Met = result.Count >= result.Required
+		results = append(results, result)
+		periodStart = periodEnd
+	}
+
+	return results
+}
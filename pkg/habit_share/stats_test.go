@@ -0,0 +1,141 @@
+package habit_share
+
+import (
+	"testing"
+	"time"
+)
+
+// dates must be in order
+func testActivities(dates []string, status string) []Activity {
+	activities := make([]Activity, 0, len(dates))
+	for _, date := range dates {
+		logged, _ := time.Parse(DateFormat, date)
+		activities = append(activities, Activity{Logged: Time{Time: logged}, Status: status})
+	}
+	return activities
+}
+
+func TestStats(t *testing.T) {
+	// a Wednesday
+	today := time.Date(2022, time.May, 25, 0, 0, 0, 0, time.UTC)
+	habit := Habit{Id: "habitId", Frequency: 2}
+	activities := testActivities([]string{
+		"2022-04-25",
+		"2022-04-27",
+		"2022-05-02",
+		"2022-05-03",
+		"2022-05-10",
+		"2022-05-16",
+		"2022-05-20",
+	}, ActivitySuccess)
+	activities[3].Status = ActivityMinimum
+
+	t.Run("should split activities into weeks", func(t *testing.T) {
+		periods := EvaluatePeriods(habit, activities, nil, today)
+
+		if len(periods) != 5 {
+			t.Fatal("expected 5 weeks got", len(periods))
+		}
+		if periods[0].Start.Format(DateFormat) != "2022-04-25" {
+			t.Error("expected first week to start 2022-04-25 got", periods[0].Start.Format(DateFormat))
+		}
+		if !periods[1].Met || periods[1].Count != 2 {
+			t.Error("expected MINIMUM to count towards the week", periods[1])
+		}
+		if periods[2].Met {
+			t.Error("expected week of 2022-05-09 to be missed")
+		}
+		if !periods[4].Current || periods[4].Met {
+			t.Error("expected the last week to be the current unmet week", periods[4])
+		}
+	})
+
+	t.Run("should calculate streaks and completion rates", func(t *testing.T) {
+		stats := CalculateStats(EvaluatePeriods(habit, activities, nil, today), today)
+
+		if stats.CurrentStreak != 1 {
+			t.Error("expected current streak of 1 got", stats.CurrentStreak)
+		}
+		if stats.LongestStreak != 2 {
+			t.Error("expected longest streak of 2 got", stats.LongestStreak)
+		}
+		if stats.CompletionRates.Last30Days != 7.0/8.0 {
+			t.Error("expected 7/8 completed in the last 30 days got", stats.CompletionRates.Last30Days)
+		}
+	})
+
+	t.Run("should not break streaks on vacation", func(t *testing.T) {
+		start, _ := time.Parse(DateFormat, "2022-05-09")
+		end, _ := time.Parse(DateFormat, "2022-05-12")
+		vacations := []Vacation{{Start: Time{Time: start}, End: Time{Time: end}}}
+
+		stats := CalculateStats(EvaluatePeriods(habit, activities, vacations, today), today)
+
+		if stats.CurrentStreak != 3 {
+			t.Error("expected current streak of 3 got", stats.CurrentStreak)
+		}
+	})
+
+	t.Run("should only require scheduled days", func(t *testing.T) {
+		scheduled := Habit{
+			Id:        "habitId",
+			Frequency: 2,
+			Schedule:  Schedule{Weekdays: []time.Weekday{time.Monday, time.Wednesday}},
+		}
+		activities := testActivities([]string{"2022-05-16", "2022-05-23"}, ActivitySuccess)
+
+		periods := EvaluatePeriods(scheduled, activities, nil, today)
+
+		if len(periods) != 2 {
+			t.Fatal("expected 2 weeks got", len(periods))
+		}
+		if periods[0].Met || periods[0].Required != 2 {
+			t.Error("expected missing Wednesday to miss the week", periods[0])
+		}
+		// Wednesday isn't over yet
+		if !periods[1].Met || periods[1].Required != 1 {
+			t.Error("expected the current week to be met so far", periods[1])
+		}
+	})
+
+	t.Run("should keep the longest streak from over StatsDays ago", func(t *testing.T) {
+		activities := testActivities([]string{
+			"2020-05-04",
+			"2020-05-05",
+			"2020-05-11",
+			"2020-05-12",
+			"2020-05-18",
+			"2020-05-19",
+			"2022-05-23",
+		}, ActivitySuccess)
+
+		stats := CalculateStats(EvaluatePeriods(habit, activities, nil, today), today)
+
+		if stats.LongestStreak != 3 {
+			t.Error("expected longest streak of 3 got", stats.LongestStreak)
+		}
+		if len(stats.Periods) == 0 || stats.Periods[0].End.Before(today.AddDate(0, 0, -StatsDays)) {
+			t.Error("expected only the periods in the last StatsDays got from", stats.Periods[0].Start)
+		}
+	})
+
+	t.Run("should not break streaks on the first period like StreakScore", func(t *testing.T) {
+		// started on the Friday so couldn't do 2 that week
+		activities := testActivities([]string{
+			"2022-05-13",
+			"2022-05-16",
+			"2022-05-17",
+			"2022-05-23",
+		}, ActivitySuccess)
+
+		stats := CalculateStats(EvaluatePeriods(habit, activities, nil, today), today)
+
+		if stats.CurrentStreak != 2 {
+			t.Error("expected current streak of 2 got", stats.CurrentStreak)
+		}
+		// every success is part of the streak
+		if score := (StreakScore{}).Score(habit, activities, nil, today); score != 4 {
+			t.Error("expected streak score of 4 got", score)
+		}
+	})
+}
@@ -37,3 +37,11 @@ func (t *Time) MarshalJSON() ([]byte, error) {
 	b = append(b, '"')
 	return b, nil
 }
+
+// LogicalDay is the date now falls on for someone in loc whose day doesn't
+// end until dayStartHour, e.g. with a dayStartHour of 2 then 1am still counts
+// as yesterday. Like every other date it is returned as midnight UTC.
+func LogicalDay(now time.Time, loc *time.Location, dayStartHour int) Time {
+	local := now.In(loc).Add(-time.Duration(dayStartHour) * time.Hour)
+	return Time{Time: time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)}
+}
@@ -0,0 +1,32 @@
+package habit_share
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogicalDay(t *testing.T) {
+	brisbane := time.FixedZone("UTC+10", 10*60*60)
+
+	t.Run("should use the date of the timezone", func(t *testing.T) {
+		// Sunday night in UTC is already Monday morning in Brisbane
+		now := time.Date(2022, time.May, 22, 23, 0, 0, 0, time.UTC)
+
+		day := LogicalDay(now, brisbane, 0)
+
+		if day.Format(DateFormat) != "2022-05-23" {
+			t.Error("expected 2022-05-23 got", day.Format(DateFormat))
+		}
+	})
+
+	t.Run("should count hours before the day start as yesterday", func(t *testing.T) {
+		// 1am in Brisbane
+		now := time.Date(2022, time.May, 22, 15, 0, 0, 0, time.UTC)
+
+		day := LogicalDay(now, brisbane, 2)
+
+		if day.Format(DateFormat) != "2022-05-22" {
+			t.Error("expected 2022-05-22 got", day.Format(DateFormat))
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package habit_share
+
+import (
+	"errors"
+	"time"
+)
+
+// Long enough for a sabbatical, short enough to not be a way of hiding a
+// habit that was given up on
+const maxVacationDays = 366
+
+var VacationNotFoundError = errors.New("Vacation could not be found")
+
+// Vacation freezes the streaks of every habit the owner has. Periods
+// overlapping a vacation can't break a streak.
+type Vacation struct {
+	Id    string
+	Owner string
+	// both Start and End are inclusive
+	Start Time
+	End   Time
+}
+
+// Overlaps is true if any day of the vacation falls in [start, end)
+func (v Vacation) Overlaps(start time.Time, end time.Time) bool {
+	return v.Start.Before(end) && !v.End.Before(start)
+}
+
+// Includes is true if day falls within the vacation
+func (v Vacation) Includes(day time.Time) bool {
+	return v.Overlaps(day, day.AddDate(0, 0, 1))
+}
+
+// OnVacation is true if any of the vacations overlap [start, end)
+func OnVacation(vacations []Vacation, start time.Time, end time.Time) bool {
+	for _, vacation := range vacations {
+		if vacation.Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateVacation freezes the current user's streaks from start to end inclusive
+func (a *App) CreateVacation(start Time, end Time) (string, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+
+	if end.Before(start.Time) {
+		return "", &InputError{StringToParse: end.Format(DateFormat)}
+	}
+	if end.Sub(start.Time) > maxVacationDays*24*time.Hour {
+		return "", &InputError{StringToParse: end.Format(DateFormat)}
+	}
+
+	id, err := a.Db.CreateVacation(Vacation{Owner: user, Start: start, End: end})
+	a.Scores.InvalidateOwner(user)
+	return id, err
+}
+
+func (a *App) GetMyVacations() ([]Vacation, error) {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Db.GetVacations(user)
+}
+
+func (a *App) DeleteVacation(id string) error {
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	err = a.Db.DeleteVacation(user, id)
+	a.Scores.InvalidateOwner(user)
+	return err
+}
+
+// GetOwnerVacation returns the vacation the habit's owner is currently on.
+// The bool is false when they aren't on vacation.
+func (a *App) GetOwnerVacation(habitId string) (Vacation, bool, error) {
+	habit, err := a.Db.GetHabit(habitId)
+	if err != nil {
+		return Vacation{}, false, err
+	}
+	if a.habitMemberCheck(habit) != nil && a.habitSharedCheck(habit) != nil {
+		return Vacation{}, false, HabitNotFoundError
+	}
+
+	vacations, err := a.Db.GetVacations(habit.Owner)
+	if err != nil {
+		return Vacation{}, false, err
+	}
+
+	today, err := a.todayFor(habit.Owner)
+	if err != nil {
+		return Vacation{}, false, err
+	}
+	for _, vacation := range vacations {
+		if vacation.Includes(today.Time) {
+			return vacation, true, nil
+		}
+	}
+	return Vacation{}, false, nil
+}
@@ -11,11 +11,11 @@ func TestActivity(t *testing.T) {
 		testUsers, testHabits := generateTestData()
 		habitShare := HabitShareFile{Users: testUsers, Habits: testHabits}
 
-		activityId, err := habitShare.CreateActivity(
-			"testUser1_habitId1",
-			habit_share.Time{Time: time.Date(1, time.January, 1, 1, 1, 1, 1, time.Local)},
-			"SUCCESS",
-		)
+		activityId, err := habitShare.CreateActivity(habit_share.Activity{
+			HabitId: "testUser1_habitId1",
+			Logged:  habit_share.Time{Time: time.Date(1, time.January, 1, 1, 1, 1, 1, time.Local)},
+			Status:  "SUCCESS",
+		})
 
 		if err != nil {
 			t.Fatal("CreateActivity returned error unexpectedly:", err)
@@ -29,49 +29,47 @@ func TestActivity(t *testing.T) {
 			t.Fatal("Activity was not added to list")
 		}
 	})
-	t.Run("should delete activity", func(t *testing.T) {
+	t.Run("should register an activity for each member", func(t *testing.T) {
 		testUsers, testHabits := generateTestData()
 		habitShare := HabitShareFile{Users: testUsers, Habits: testHabits}
-
-		err := habitShare.DeleteActivity("testUser2_habitId1", "testUser2_habitId1_2001-01-01")
-
-		if err != nil {
-			t.Fatal("DeleteActivity returned error unexpectedly:", err)
+		logged := habit_share.Time{Time: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)}
+
+		for _, member := range []string{"testUser1", "test_user2"} {
+			activityId, err := habitShare.CreateActivity(habit_share.Activity{
+				HabitId: "testUser1_habitId1",
+				Logged:  logged,
+				Status:  "SUCCESS",
+				Member:  member,
+			})
+			if err != nil {
+				t.Fatal("CreateActivity returned error unexpectedly:", err)
+			}
+			if activityId != "testUser1_habitId1_0001-01-01_"+member {
+				t.Fatal("CreateActivity did not return correct id got: ", activityId)
+			}
 		}
 
-		if len(habitShare.Habits["testUser2_habitId1"].Activities) != 0 {
-			t.Fatal("Activity was not deleted")
-		}
-	})
-
-	t.Run("should calcuate score is 0", func(t *testing.T) {
-		testUsers, testHabits := generateTestData()
-		habitShare := HabitShareFile{Users: testUsers, Habits: testHabits}
-
-		score, err := habitShare.GetScore("testUser1_habitId1")
-
+		err := habitShare.DeleteActivity("testUser1_habitId1", "testUser1_habitId1_0001-01-01_test_user2")
 		if err != nil {
-			t.Fatal("GetScore returned error unexpectedly:", err)
+			t.Fatal("DeleteActivity returned error unexpectedly:", err)
 		}
-
-		if score != 0 {
-			t.Fatal("Score not calculated properly expected 0 got:", score)
+		activities := habitShare.Habits["testUser1_habitId1"].Activities
+		if len(activities) != 1 || activities[0].Member != "testUser1" {
+			t.Fatal("Wrong member's activity was deleted, left with: ", activities)
 		}
 	})
-
-	t.Run("should calcuate score is 1", func(t *testing.T) {
+	t.Run("should delete activity", func(t *testing.T) {
 		testUsers, testHabits := generateTestData()
 		habitShare := HabitShareFile{Users: testUsers, Habits: testHabits}
-		_, err := habitShare.CreateActivity("testUser2_habitId1", habit_share.Time{Time: time.Now()}, "SUCCESS")
 
-		score, err := habitShare.GetScore("testUser2_habitId1")
+		err := habitShare.DeleteActivity("testUser2_habitId1", "testUser2_habitId1_2001-01-01")
 
 		if err != nil {
-			t.Fatal("GetScore returned error unexpectedly:", err)
+			t.Fatal("DeleteActivity returned error unexpectedly:", err)
 		}
 
-		if score != 1 {
-			t.Fatal("Score not calculated properly expected 1 got:", score)
+		if len(habitShare.Habits["testUser2_habitId1"].Activities) != 0 {
+			t.Fatal("Activity was not deleted")
 		}
 	})
 
@@ -0,0 +1,96 @@
+package habit_share_file
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestChallenge(t *testing.T) {
+	t.Run("should list a participant's challenges by start", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+
+		start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		// created out of order to check they are sorted
+		for _, days := range []int{2, 0, 1} {
+			_, err := habitShare.CreateChallenge(habit_share.Challenge{
+				Organizer:    "owner",
+				Participants: []string{"friend", "owner"},
+				Start:        habit_share.Time{Time: start.AddDate(0, 0, days)},
+				End:          habit_share.Time{Time: start.AddDate(0, 0, 30)},
+			})
+			if err != nil {
+				t.Fatal("expected no error got ", err)
+			}
+		}
+		if _, err := habitShare.CreateChallenge(habit_share.Challenge{Organizer: "other", Participants: []string{"other"}}); err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+
+		challenges, err := habitShare.GetChallenges("friend")
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if len(challenges) != 3 {
+			t.Fatalf("expected 3 challenges got %v", challenges)
+		}
+		for i, challenge := range challenges {
+			if !challenge.Start.Equal(start.AddDate(0, 0, i)) {
+				t.Error("expected challenges sorted by start got ", challenges)
+			}
+		}
+	})
+
+	t.Run("should leave out finished challenges", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+		id, _ := habitShare.CreateChallenge(habit_share.Challenge{Organizer: "owner"})
+		if challenges, _ := habitShare.GetUnfinishedChallenges(); len(challenges) != 1 {
+			t.Fatalf("expected 1 unfinished challenge got %v", challenges)
+		}
+
+		challenge, _ := habitShare.GetChallenge(id)
+		challenge.Finished = true
+		if err := habitShare.SetChallenge(id, challenge); err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if challenges, _ := habitShare.GetUnfinishedChallenges(); len(challenges) != 0 {
+			t.Errorf("expected no unfinished challenges got %v", challenges)
+		}
+		if err := habitShare.SetChallenge("missing", challenge); err != habit_share.ChallengeNotFoundError {
+			t.Error("expected ChallengeNotFoundError got ", err)
+		}
+	})
+
+	t.Run("should only link a habit over the one expected", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+		id, _ := habitShare.CreateChallenge(habit_share.Challenge{Organizer: "owner"})
+
+		if err := habitShare.LinkChallengeHabit(id, "friend", "", "first"); err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		err := habitShare.LinkChallengeHabit(id, "friend", "", "second")
+		if inputError := (*habit_share.InputError)(nil); !errors.As(err, &inputError) {
+			t.Error("expected InputError got ", err)
+		}
+		if err := habitShare.LinkChallengeHabit(id, "friend", "first", "third"); err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if challenge, _ := habitShare.GetChallenge(id); challenge.Habits["friend"] != "third" {
+			t.Errorf("expected third to be linked got %v", challenge.Habits)
+		}
+		if err := habitShare.LinkChallengeHabit("missing", "friend", "", "first"); err != habit_share.ChallengeNotFoundError {
+			t.Error("expected ChallengeNotFoundError got ", err)
+		}
+	})
+}
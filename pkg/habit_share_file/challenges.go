@@ -0,0 +1,124 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+
+	"github.com/google/uuid"
+)
+
+var _ habit_share.ChallengesDatabase = (*HabitShareFile)(nil)
+
+// CreateChallenge implements habit_share.ChallengesDatabase
+func (a *HabitShareFile) CreateChallenge(newChallenge habit_share.Challenge) (string, error) {
+	if err := a.read(); err != nil {
+		return "", err
+	}
+	newChallenge.Id = uuid.NewString()
+	a.Challenges[newChallenge.Id] = newChallenge
+
+	err := a.write()
+	if err != nil {
+		return newChallenge.Id, err
+	}
+
+	return newChallenge.Id, nil
+}
+
+// GetChallenge implements habit_share.ChallengesDatabase
+func (a *HabitShareFile) GetChallenge(id string) (habit_share.Challenge, error) {
+	if err := a.read(); err != nil {
+		return habit_share.Challenge{}, err
+	}
+
+	challenge, ok := a.Challenges[id]
+	if !ok {
+		return habit_share.Challenge{}, habit_share.ChallengeNotFoundError
+	}
+	return challenge, nil
+}
+
+// GetChallenges implements habit_share.ChallengesDatabase
+func (a *HabitShareFile) GetChallenges(participant string) ([]habit_share.Challenge, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	challenges := make([]habit_share.Challenge, 0)
+	for _, challenge := range a.Challenges {
+		if challenge.HasParticipant(participant) {
+			challenges = append(challenges, challenge)
+		}
+	}
+
+	// map does not guarantee this is in order
+	sort.Slice(challenges, func(i, j int) bool {
+		if !challenges[i].Start.Equal(challenges[j].Start.Time) {
+			return challenges[i].Start.Before(challenges[j].Start.Time)
+		}
+		return challenges[i].Id < challenges[j].Id
+	})
+	return challenges, nil
+}
+
+// GetUnfinishedChallenges implements habit_share.ChallengesDatabase
+func (a *HabitShareFile) GetUnfinishedChallenges() ([]habit_share.Challenge, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	challenges := make([]habit_share.Challenge, 0)
+	for _, challenge := range a.Challenges {
+		if !challenge.Finished {
+			challenges = append(challenges, challenge)
+		}
+	}
+
+	sort.Slice(challenges, func(i, j int) bool {
+		if !challenges[i].End.Equal(challenges[j].End.Time) {
+			return challenges[i].End.Before(challenges[j].End.Time)
+		}
+		return challenges[i].Id < challenges[j].Id
+	})
+	return challenges, nil
+}
+
+// SetChallenge implements habit_share.ChallengesDatabase
+func (a *HabitShareFile) SetChallenge(id string, updatedChallenge habit_share.Challenge) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+	if _, ok := a.Challenges[id]; !ok {
+		return habit_share.ChallengeNotFoundError
+	}
+
+	a.Challenges[id] = updatedChallenge
+
+	return a.write()
+}
+
+// LinkChallengeHabit implements habit_share.ChallengesDatabase
+func (a *HabitShareFile) LinkChallengeHabit(id string, participant string, previous string, habitId string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+	challenge, ok := a.Challenges[id]
+	if !ok {
+		return habit_share.ChallengeNotFoundError
+	}
+	if challenge.Habits[participant] != previous {
+		return &habit_share.InputError{StringToParse: id}
+	}
+
+	// the stored map isn't changed in place in case it's shared with a copy
+	habits := make(map[string]string, len(challenge.Habits)+1)
+	for other, otherHabitId := range challenge.Habits {
+		habits[other] = otherHabitId
+	}
+	habits[participant] = habitId
+	challenge.Habits = habits
+	a.Challenges[id] = challenge
+
+	return a.write()
+}
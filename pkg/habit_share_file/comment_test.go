@@ -0,0 +1,51 @@
+package habit_share_file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestComment(t *testing.T) {
+	t.Run("should page back from the newest comment", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+		habitId, err := habitShare.CreateHabit(habit_share.Habit{Name: "new habit", Owner: "owner", Frequency: 2})
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+
+		start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		// created out of order to check they are kept sorted
+		for _, hours := range []int{2, 0, 3, 1} {
+			_, err := habitShare.CreateComment(habit_share.Comment{
+				HabitId: habitId,
+				Author:  "owner",
+				Body:    "comment",
+				Created: start.Add(time.Duration(hours) * time.Hour),
+			})
+			if err != nil {
+				t.Fatal("expected no error got ", err)
+			}
+		}
+
+		comments, hasMore, err := habitShare.GetComments(habitId, start.Add(3*time.Hour), 2)
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if !hasMore || len(comments) != 2 {
+			t.Fatalf("expected 2 comments with more got %d %v", len(comments), hasMore)
+		}
+		if !comments[0].Created.Equal(start.Add(2*time.Hour)) || !comments[1].Created.Equal(start.Add(time.Hour)) {
+			t.Error("expected the comments before the third hour newest first got ", comments)
+		}
+
+		comments, hasMore, _ = habitShare.GetComments(habitId, comments[1].Created, 2)
+		if hasMore || len(comments) != 1 || !comments[0].Created.Equal(start) {
+			t.Error("expected only the first comment got ", comments)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package habit_share_file
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+
+	"github.com/google/uuid"
+)
+
+var _ habit_share.CommentsDatabase = (*HabitShareFile)(nil)
+
+// CreateComment implements habit_share.CommentsDatabase
+func (a *HabitShareFile) CreateComment(newComment habit_share.Comment) (string, error) {
+	if err := a.read(); err != nil {
+		return "", err
+	}
+	habit, ok := a.Habits[newComment.HabitId]
+	if !ok {
+		return "", habit_share.HabitNotFoundError
+	}
+
+	newComment.Id = uuid.NewString()
+	appended := append(habit.Comments, newComment)
+	// comments are nearly always newer than the rest
+	sort.SliceStable(appended, func(i, j int) bool {
+		return appended[i].Created.Before(appended[j].Created)
+	})
+	habit.Comments = appended
+	a.Habits[newComment.HabitId] = habit
+
+	err := a.write()
+	if err != nil {
+		return newComment.Id, err
+	}
+
+	return newComment.Id, nil
+}
+
+func (a *HabitShareFile) commentIndex(habitId string, id string) (int, error) {
+	habit, ok := a.Habits[habitId]
+	if !ok {
+		return 0, habit_share.HabitNotFoundError
+	}
+	for i, comment := range habit.Comments {
+		if comment.Id == id {
+			return i, nil
+		}
+	}
+	return 0, habit_share.CommentNotFoundError
+}
+
+// GetComment implements habit_share.CommentsDatabase
+func (a *HabitShareFile) GetComment(habitId string, id string) (habit_share.Comment, error) {
+	if err := a.read(); err != nil {
+		return habit_share.Comment{}, err
+	}
+
+	i, err := a.commentIndex(habitId, id)
+	if err != nil {
+		return habit_share.Comment{}, err
+	}
+	return a.Habits[habitId].Comments[i], nil
+}
+
+// GetComments implements habit_share.CommentsDatabase
+func (a *HabitShareFile) GetComments(
+	habitId string,
+	before time.Time,
+	limit int,
+) (comments []habit_share.Comment, hasMore bool, err error) {
+	if err := a.read(); err != nil {
+		return nil, false, err
+	}
+	habit, ok := a.Habits[habitId]
+	if !ok {
+		return nil, false, habit_share.HabitNotFoundError
+	}
+
+	r := sort.Search(len(habit.Comments), func(i int) bool {
+		return !habit.Comments[i].Created.Before(before)
+	})
+	l := 0
+	if r-limit > 0 {
+		hasMore = true
+		l = r - limit
+	}
+
+	comments = make([]habit_share.Comment, 0, r-l)
+	for i := r - 1; i >= l; i-- {
+		comments = append(comments, habit.Comments[i])
+	}
+
+	return comments, hasMore, nil
+}
+
+// SetComment implements habit_share.CommentsDatabase
+func (a *HabitShareFile) SetComment(habitId string, updatedComment habit_share.Comment) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	i, err := a.commentIndex(habitId, updatedComment.Id)
+	if err != nil {
+		return err
+	}
+	// Created is kept so the comments stay sorted
+	updatedComment.Created = a.Habits[habitId].Comments[i].Created
+	a.Habits[habitId].Comments[i] = updatedComment
+
+	return a.write()
+}
+
+// DeleteComment implements habit_share.CommentsDatabase
+func (a *HabitShareFile) DeleteComment(habitId string, id string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	i, err := a.commentIndex(habitId, id)
+	if err != nil {
+		return err
+	}
+	habit := a.Habits[habitId]
+	habit.Comments = append(habit.Comments[:i], habit.Comments[i+1:]...)
+	a.Habits[habitId] = habit
+
+	return a.write()
+}
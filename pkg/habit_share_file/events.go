@@ -0,0 +1,73 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+var _ habit_share.EventsDatabase = (*HabitShareFile)(nil)
+
+// how many of the latest events are kept, older ones drop out of every feed
+const maxEvents = 10000
+
+// AppendEvent implements habit_share.EventsDatabase
+func (a *HabitShareFile) AppendEvent(newEvent habit_share.Event) (int64, error) {
+	if err := a.read(); err != nil {
+		return 0, err
+	}
+
+	a.LastEventId++
+	newEvent.Id = a.LastEventId
+	a.Events = append(a.Events, newEvent)
+	if len(a.Events) > maxEvents {
+		// copy so the dropped events can be freed
+		a.Events = append(make([]habit_share.Event, 0, maxEvents), a.Events[len(a.Events)-maxEvents:]...)
+	}
+
+	err := a.write()
+	if err != nil {
+		return newEvent.Id, err
+	}
+
+	return newEvent.Id, nil
+}
+
+// GetFeed implements habit_share.EventsDatabase
+// TODO this scans every event, an index by user would be needed if it grows
+func (a *HabitShareFile) GetFeed(
+	user string,
+	before int64,
+	limit int,
+) (events []habit_share.Event, hasMore bool, err error) {
+	if err := a.read(); err != nil {
+		return nil, false, err
+	}
+
+	end := len(a.Events)
+	if before > 0 {
+		end = sort.Search(len(a.Events), func(i int) bool {
+			return a.Events[i].Id >= before
+		})
+	}
+
+	events = make([]habit_share.Event, 0)
+	for i := end - 1; i >= 0; i-- {
+		if !inAudience(a.Events[i], user) {
+			continue
+		}
+		if len(events) == limit {
+			hasMore = true
+			break
+		}
+		events = append(events, a.Events[i])
+	}
+
+	return events, hasMore, nil
+}
+
+func inAudience(event habit_share.Event, user string) bool {
+	// the audience is sorted
+	i := sort.SearchStrings(event.Audience, user)
+	return i < len(event.Audience) && event.Audience[i] == user
+}
@@ -0,0 +1,30 @@
+package habit_share_file
+
+import (
+	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestEvents(t *testing.T) {
+	t.Run("should only keep the latest events", func(t *testing.T) {
+		habitShare := HabitShareFile{Users: map[string]User{}, Habits: map[string]HabitJson{}}
+
+		for i := 0; i < maxEvents+2; i++ {
+			if _, err := habitShare.AppendEvent(habit_share.Event{Audience: []string{"user"}}); err != nil {
+				t.Fatal("expected no error got ", err)
+			}
+		}
+
+		if len(habitShare.Events) != maxEvents || habitShare.Events[0].Id != 3 {
+			t.Fatalf("expected %d events from id 3 got %d from %d", maxEvents, len(habitShare.Events), habitShare.Events[0].Id)
+		}
+		events, hasMore, err := habitShare.GetFeed("user", 4, 10)
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if len(events) != 1 || events[0].Id != 3 || hasMore {
+			t.Error("expected only event 3 before 4 got ", events, hasMore)
+		}
+	})
+}
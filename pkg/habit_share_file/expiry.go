@@ -0,0 +1,32 @@
+package habit_share_file
+
+import "time"
+
+// RemoveExpiredShares unshares every share that has expired by now, returning
+// how many were removed
+func (a *HabitShareFile) RemoveExpiredShares(now time.Time) (int, error) {
+	if err := a.read(); err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for habitId, habit := range a.Habits {
+		for friend, expires := range habit.ShareExpiries {
+			if now.Before(expires) {
+				continue
+			}
+			delete(habit.SharedWith, friend)
+			delete(habit.ShareExpiries, friend)
+			if user, ok := a.Users[friend]; ok {
+				delete(user.SharedHabits, habitId)
+				delete(user.Muted, habitId)
+			}
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	return removed, a.write()
+}
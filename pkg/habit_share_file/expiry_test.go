@@ -0,0 +1,51 @@
+package habit_share_file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestExpiry(t *testing.T) {
+	t.Run("should remove only expired shares", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+		habitId, err := habitShare.CreateHabit(habit_share.Habit{Name: "new habit", Owner: "owner", Frequency: 2})
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+
+		now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		expired := now.Add(-time.Hour)
+		later := now.Add(time.Hour)
+		shares := map[string]*time.Time{"expired": &expired, "later": &later, "never": nil}
+		for friend, expires := range shares {
+			err := habitShare.ShareHabit(habitId, friend, habit_share.PermissionView, expires)
+			if err != nil {
+				t.Fatal("expected no error got ", err)
+			}
+		}
+
+		removed, err := habitShare.RemoveExpiredShares(now)
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if removed != 1 {
+			t.Errorf("expected 1 share removed got %d", removed)
+		}
+
+		habit := habitShare.Habits[habitId]
+		if _, ok := habit.SharedWith["expired"]; ok {
+			t.Error("expired share is still in SharedWith")
+		}
+		if _, ok := habitShare.Users["expired"].SharedHabits[habitId]; ok {
+			t.Error("expired share is still in the user's SharedHabits")
+		}
+		if len(habit.SharedWith) != 2 || len(habit.ShareExpiries) != 1 {
+			t.Errorf("expected the other shares to remain got %v expiring %v", habit.SharedWith, habit.ShareExpiries)
+		}
+	})
+}
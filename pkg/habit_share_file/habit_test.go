@@ -56,6 +56,27 @@ func TestHabit(t *testing.T) {
 		}
 	})
 
+	t.Run("should list unarchived habits of every user", func(t *testing.T) {
+		habitShare := HabitShareFile{Users: map[string]User{}, Habits: map[string]HabitJson{}}
+
+		mine, _ := habitShare.CreateHabit(habit_share.Habit{Name: "mine", Owner: "owner", Frequency: 2})
+		theirs, _ := habitShare.CreateHabit(habit_share.Habit{Name: "theirs", Owner: "other", Frequency: 2})
+		habitShare.CreateHabit(habit_share.Habit{Name: "archived", Owner: "owner", Frequency: 2, Archived: true})
+
+		habits, err := habitShare.GetUnarchivedHabits()
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if len(habits) != 2 {
+			t.Fatal("expected 2 habits got ", habits)
+		}
+		for _, habit := range habits {
+			if habit.Id != mine && habit.Id != theirs {
+				t.Error("did not expect ", habit)
+			}
+		}
+	})
+
 	t.Run("should archive a habit", func(t *testing.T) {
 		habitShare := HabitShareFile{Users: map[string]User{}, Habits: map[string]HabitJson{}}
 
@@ -142,7 +163,7 @@ func TestHabit(t *testing.T) {
 			t.Fatal("expected no error got ", err)
 		}
 
-		err = habitShare.ShareHabit(habitId, "oldUser")
+		err = habitShare.ShareHabit(habitId, "oldUser", habit_share.PermissionView, nil)
 		if err != nil {
 			t.Fatal("expected no error got ", err)
 		}
@@ -179,7 +200,7 @@ func TestHabit(t *testing.T) {
 			t.Fatal("expected no error got ", err)
 		}
 
-		err = habitShare.ShareHabit(habitId, "oldUser")
+		err = habitShare.ShareHabit(habitId, "oldUser", habit_share.PermissionView, nil)
 		if err != nil {
 			t.Fatal("expected no error got ", err)
 		}
@@ -0,0 +1,46 @@
+package habit_share_file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestInvitation(t *testing.T) {
+	t.Run("should create, list and delete invitations", func(t *testing.T) {
+		testUsers, testHabits := generateTestData()
+		habitShare := HabitShareFile{Users: testUsers, Habits: testHabits}
+
+		sent := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+		invitationId, err := habitShare.CreateInvitation(habit_share.Invitation{
+			HabitId: "testUser1_habitId1",
+			From:    "testUser1",
+			To:      "testUser2",
+			Sent:    sent,
+			Expires: sent.Add(habit_share.InvitationTtl),
+		})
+		if err != nil {
+			t.Fatal("CreateInvitation returned error unexpectedly:", err)
+		}
+
+		received, err := habitShare.GetReceivedInvitations("testUser2")
+		if err != nil {
+			t.Fatal("GetReceivedInvitations returned error unexpectedly:", err)
+		}
+		if len(received) != 1 || received[0].Id != invitationId {
+			t.Fatal("invitation was not stored got:", received)
+		}
+		if sent, _ := habitShare.GetSentInvitations("testUser2"); len(sent) != 0 {
+			t.Fatal("expected testUser2 to have sent nothing got:", sent)
+		}
+
+		err = habitShare.DeleteInvitation(invitationId)
+		if err != nil {
+			t.Fatal("DeleteInvitation returned error unexpectedly:", err)
+		}
+		if _, err := habitShare.GetInvitation(invitationId); err != habit_share.InvitationNotFoundError {
+			t.Fatal("expected invitation not found got:", err)
+		}
+	})
+}
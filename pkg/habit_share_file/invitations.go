@@ -0,0 +1,89 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvitation implements habit_share.HabitsDatabase
+func (a *HabitShareFile) CreateInvitation(newInvitation habit_share.Invitation) (string, error) {
+	if err := a.read(); err != nil {
+		return "", err
+	}
+	newInvitation.Id = uuid.NewString()
+	a.Invitations[newInvitation.Id] = newInvitation
+
+	err := a.write()
+	if err != nil {
+		return newInvitation.Id, err
+	}
+
+	return newInvitation.Id, nil
+}
+
+// GetInvitation implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetInvitation(id string) (habit_share.Invitation, error) {
+	if err := a.read(); err != nil {
+		return habit_share.Invitation{}, err
+	}
+
+	invitation, ok := a.Invitations[id]
+	if !ok {
+		return habit_share.Invitation{}, habit_share.InvitationNotFoundError
+	}
+	return invitation, nil
+}
+
+// GetReceivedInvitations implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetReceivedInvitations(to string) ([]habit_share.Invitation, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	return a.invitationsWhere(func(invitation habit_share.Invitation) bool {
+		return invitation.To == to
+	}), nil
+}
+
+// GetSentInvitations implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetSentInvitations(from string) ([]habit_share.Invitation, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	return a.invitationsWhere(func(invitation habit_share.Invitation) bool {
+		return invitation.From == from
+	}), nil
+}
+
+func (a *HabitShareFile) invitationsWhere(match func(habit_share.Invitation) bool) []habit_share.Invitation {
+	invitations := make([]habit_share.Invitation, 0)
+	for _, invitation := range a.Invitations {
+		if match(invitation) {
+			invitations = append(invitations, invitation)
+		}
+	}
+
+	// map does not guarantee this is in order
+	sort.Slice(invitations, func(i, j int) bool {
+		return invitations[i].Sent.Before(invitations[j].Sent)
+	})
+	return invitations
+}
+
+// DeleteInvitation implements habit_share.HabitsDatabase
+func (a *HabitShareFile) DeleteInvitation(id string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	if _, ok := a.Invitations[id]; !ok {
+		return habit_share.InvitationNotFoundError
+	}
+	delete(a.Invitations, id)
+
+	return a.write()
+}
@@ -3,6 +3,8 @@ package habit_share_file
 import (
 	"os"
 	"testing"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
 )
 
 var inputJson = "input.json"
@@ -60,6 +62,21 @@ func TestIo(t *testing.T) {
 		habit.Description = "new description"
 	})
 
+	t.Run("should read period and default to weekly when missing", func(t *testing.T) {
+		habitShare, err := HabitShareFromFile(inputJson)
+		if err != nil {
+			t.Error("Failed to parse or read the input file got: ", err)
+		}
+
+		monthly := habitShare.Habits["testUser1_habitId1"].Period
+		if monthly.Unit != habit_share.PeriodMonth || monthly.Length != 1 {
+			t.Errorf("Expected a monthly period got %+v", monthly)
+		}
+		if !habitShare.Habits["testUser2_habitId1"].Period.IsWeekly() {
+			t.Errorf("Expected missing period to be weekly got %+v", habitShare.Habits["testUser2_habitId1"].Period)
+		}
+	})
+
 	t.Run("should load nothing but continue if file doesn't exist", func(t *testing.T) {
 		_, err := HabitShareFromFile("doesn't exist")
 		if err != nil {
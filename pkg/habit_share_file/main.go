@@ -26,21 +26,23 @@ func ConstructActivityId(habitId string, logged habit_share.Time) string {
 	return fmt.Sprintf("%s_%s", habitId, logged.Format(habit_share.DateFormat))
 }
 
-func parseActivityId(activityId string) (habitId string, date habit_share.Time, err error) {
-	lastIndex := strings.LastIndex(activityId, "_")
-	if lastIndex == -1 {
-		err = &habit_share.InputError{StringToParse: activityId}
-		return
-	}
+// ConstructMemberActivityId is the id of what a member of a group habit logged
+// on the day
+func ConstructMemberActivityId(habitId string, logged habit_share.Time, member string) string {
+	return fmt.Sprintf("%s_%s", ConstructActivityId(habitId, logged), member)
+}
 
-	habitId = activityId[:lastIndex]
-	dateString := activityId[lastIndex+1:]
-	date.UnmarshalText([]byte(dateString))
-	if err != nil {
-		err = &habit_share.InputError{StringToParse: activityId}
-		return
+func parseActivityId(activityId string) (habitId string, date habit_share.Time, err error) {
+	// the habit id and member may contain _ so look for the date from the end
+	for i := strings.LastIndex(activityId, "_"); i != -1; i = strings.LastIndex(activityId[:i], "_") {
+		dateString, _, _ := strings.Cut(activityId[i+1:], "_")
+		if date.UnmarshalText([]byte(dateString)) == nil {
+			habitId = activityId[:i]
+			return
+		}
 	}
 
+	err = &habit_share.InputError{StringToParse: activityId}
 	return
 }
 
@@ -54,20 +56,39 @@ After every setting we save it to the file.
 type HabitJson struct {
 	habit_share.Habit
 	Activities []habit_share.Activity
+	Reactions  []habit_share.Reaction
+	// sorted by Created
+	Comments []habit_share.Comment
 }
 
 type User struct {
 	MyHabits     map[string]struct{}
 	SharedHabits map[string]struct{}
+	// shared habits hidden from SharedHabits' listing
+	Muted map[string]struct{}
 	// an in memory solution would use pointers but JSONs can't parse pointers
+	Settings habit_share.UserSettings
 }
 
 type HabitShareFile struct {
-	Users    map[string]User
-	Habits   map[string]HabitJson
-	filename string
-	fileLock *sync.Mutex // This can't be a rw mutex as you're always "writing" the parsed file to the struct
-	lastRead time.Time
+	Users  map[string]User
+	Habits map[string]HabitJson
+	// keyed by owner
+	Vacations map[string][]habit_share.Vacation
+	// keyed by id
+	Invitations map[string]habit_share.Invitation
+	// keyed by token
+	PublicLinks map[string]habit_share.PublicLink
+	// keyed by id
+	Challenges map[string]habit_share.Challenge
+	// keyed by user, oldest first
+	Notifications map[string][]habit_share.Notification
+	// sorted by Id
+	Events      []habit_share.Event
+	LastEventId int64
+	filename    string
+	fileLock    *sync.Mutex // This can't be a rw mutex as you're always "writing" the parsed file to the struct
+	lastRead    time.Time
 }
 
 var _ habit_share.HabitsDatabase = (*HabitShareFile)(nil)
@@ -153,21 +174,53 @@ func (a *HabitShareFile) read() error {
 			// file does not exist or got removed
 			a.Habits = make(map[string]HabitJson, 0)
 			a.Users = make(map[string]User, 0)
+			a.Vacations = make(map[string][]habit_share.Vacation, 0)
+			a.Invitations = make(map[string]habit_share.Invitation, 0)
+			a.PublicLinks = make(map[string]habit_share.PublicLink, 0)
+			a.Challenges = make(map[string]habit_share.Challenge, 0)
+			a.Notifications = make(map[string][]habit_share.Notification, 0)
+			a.Events = make([]habit_share.Event, 0)
+			a.LastEventId = 0
 			return nil
 		}
 		err = json.Unmarshal(content, a)
 		if err != nil {
 			return err
 		}
-
-		return nil
 	}
 
+	a.makeMaps()
 	return nil
 }
 
+// makeMaps makes the maps that are missing. Files written before a map was
+// added won't have it and neither will databases made in memory.
+func (a *HabitShareFile) makeMaps() {
+	if a.Habits == nil {
+		a.Habits = make(map[string]HabitJson, 0)
+	}
+	if a.Users == nil {
+		a.Users = make(map[string]User, 0)
+	}
+	if a.Vacations == nil {
+		a.Vacations = make(map[string][]habit_share.Vacation, 0)
+	}
+	if a.Invitations == nil {
+		a.Invitations = make(map[string]habit_share.Invitation, 0)
+	}
+	if a.PublicLinks == nil {
+		a.PublicLinks = make(map[string]habit_share.PublicLink, 0)
+	}
+	if a.Challenges == nil {
+		a.Challenges = make(map[string]habit_share.Challenge, 0)
+	}
+	if a.Notifications == nil {
+		a.Notifications = make(map[string][]habit_share.Notification, 0)
+	}
+}
+
 // ShareHabit implements habit_share.HabitsDatabase
-func (a *HabitShareFile) ShareHabit(habitId string, friend string) error {
+func (a *HabitShareFile) ShareHabit(habitId string, friend string, permission habit_share.Permission, expires *time.Time) error {
 	if err := a.read(); err != nil {
 		return err
 	}
@@ -187,7 +240,21 @@ func (a *HabitShareFile) ShareHabit(habitId string, friend string) error {
 	user.SharedHabits[habitId] = struct{}{}
 	a.Users[friend] = user
 
-	habit.SharedWith[friend] = struct{}{}
+	// habits from files that were never shared have no map
+	if habit.SharedWith == nil {
+		habit.SharedWith = make(map[string]habit_share.Permission, 0)
+		a.Habits[habitId] = habit
+	}
+	habit.SharedWith[friend] = permission
+	if expires != nil {
+		if habit.ShareExpiries == nil {
+			habit.ShareExpiries = make(map[string]time.Time)
+			a.Habits[habitId] = habit
+		}
+		habit.ShareExpiries[friend] = *expires
+	} else {
+		delete(habit.ShareExpiries, friend)
+	}
 
 	err := a.write()
 	if err != nil {
@@ -198,7 +265,7 @@ func (a *HabitShareFile) ShareHabit(habitId string, friend string) error {
 }
 
 // GetSharedWith implements habit_share.HabitsDatabase
-func (a *HabitShareFile) GetSharedWith(habitId string) (map[string]struct{}, error) {
+func (a *HabitShareFile) GetSharedWith(habitId string) (map[string]habit_share.Permission, error) {
 	if err := a.read(); err != nil {
 		return nil, err
 	}
@@ -227,9 +294,11 @@ func (a *HabitShareFile) UnShareHabit(habitId string, friend string) error {
 	}
 
 	delete(user.SharedHabits, habitId)
+	delete(user.Muted, habitId)
 	a.Users[friend] = user
 
 	delete(habit.SharedWith, friend)
+	delete(habit.ShareExpiries, friend)
 
 	err := a.write()
 	if err != nil {
@@ -266,21 +335,28 @@ func (a *HabitShareFile) CreateHabit(newHabit habit_share.Habit) (string, error)
 		return "", err
 	}
 
-	user, ok := a.Users[newHabit.Owner]
-	if !ok {
-		// if user doesn't exist create user
-		user = User{MyHabits: make(map[string]struct{}, 0), SharedHabits: make(map[string]struct{}, 0)}
-		a.Users[newHabit.Owner] = user
+	// group habits are listed for every member
+	owners := newHabit.Members
+	if len(owners) == 0 {
+		owners = []string{newHabit.Owner}
 	}
 
 	// Create new habit to ensure we don't modify newHabit parameter
 	newHabit.Id = fmt.Sprintf("%s_%s", newHabit.Owner, uuid.NewString())
-	newHabit.SharedWith = make(map[string]struct{}, 0)
+	newHabit.SharedWith = make(map[string]habit_share.Permission, 0)
 
 	// We could probably perform a collision check
 	a.Habits[newHabit.Id] = HabitJson{Habit: newHabit, Activities: make([]habit_share.Activity, 0)}
 
-	user.MyHabits[newHabit.Id] = struct{}{}
+	for _, owner := range owners {
+		user, ok := a.Users[owner]
+		if !ok {
+			// if user doesn't exist create user
+			user = User{MyHabits: make(map[string]struct{}, 0), SharedHabits: make(map[string]struct{}, 0)}
+			a.Users[owner] = user
+		}
+		user.MyHabits[newHabit.Id] = struct{}{}
+	}
 
 	err := a.write()
 	if err != nil {
@@ -291,38 +367,40 @@ func (a *HabitShareFile) CreateHabit(newHabit habit_share.Habit) (string, error)
 }
 
 // CreateActivity implements habit_share.HabitsDatabase
-// logged should be the first moments of the day under UTC. If not we transform it anyway.
-func (a *HabitShareFile) CreateActivity(habitId string, logged habit_share.Time, status string) (string, error) {
+// Logged should be the first moments of the day under UTC. If not we transform it anyway.
+// Logging on a day which already has an activity updates it.
+func (a *HabitShareFile) CreateActivity(newActivity habit_share.Activity) (string, error) {
 	if err := a.read(); err != nil {
 		return "", err
 	}
+	habitId := newActivity.HabitId
 	// activity id will be defined as habit_date
 	habit, ok := a.Habits[habitId]
 	if !ok {
 		return "", habit_share.HabitNotFoundError
 	}
 
-	activityId := ConstructActivityId(habitId, logged)
+	activityId := ConstructActivityId(habitId, newActivity.Logged)
+	if newActivity.Member != "" {
+		activityId = ConstructMemberActivityId(habitId, newActivity.Logged, newActivity.Member)
+	}
 	// check if activity with that id already exists
 	// TODO this doesn't scale
 	toAppend := true
 	for i, activity := range habit.Activities {
 		if activity.Id == activityId {
-			if activity.Status == status {
+			if activity.Status == newActivity.Status && activity.Amount == newActivity.Amount {
 				return activityId, nil
 			}
 			// update status
-			habit.Activities[i].Status = status
+			habit.Activities[i].Status = newActivity.Status
+			habit.Activities[i].Amount = newActivity.Amount
 			toAppend = false
 		}
 	}
 	if toAppend {
-		appended := append(habit.Activities, habit_share.Activity{
-			Id:      activityId,
-			HabitId: habitId,
-			Logged:  logged,
-			Status:  status,
-		})
+		newActivity.Id = activityId
+		appended := append(habit.Activities, newActivity)
 		// sort is ascending so later times are further down the array
 		sort.Slice(appended[:], func(i, j int) bool {
 			return appended[i].Logged.Before(appended[j].Logged.Time)
@@ -383,6 +461,10 @@ func (a *HabitShareFile) DeleteActivity(habitId string, id string) error {
 			habit.Activities[i].Logged.Equal(date.Time)
 	})
 
+	// group habits have an activity for each member on the day
+	for index < n && habit.Activities[index].Logged.Equal(date.Time) && habit.Activities[index].Id != id {
+		index++
+	}
 	if index == n || habit.Activities[index].Id != id {
 		log.Print(index)
 		log.Print(date.Time)
@@ -394,6 +476,9 @@ func (a *HabitShareFile) DeleteActivity(habitId string, id string) error {
 		habit.Activities[i-1] = habit.Activities[i]
 	}
 	habit.Activities = habit.Activities[:n-1]
+	habit.Reactions = reactionsWithout(habit.Reactions, func(reaction habit_share.Reaction) bool {
+		return reaction.ActivityId == id
+	})
 
 	a.Habits[habitId] = habit
 
@@ -418,6 +503,7 @@ func (a *HabitShareFile) DeleteHabit(id string) error {
 			panic("Habit shared with user that doesn't exist")
 		}
 		delete(user.SharedHabits, id)
+		delete(user.Muted, id)
 	}
 
 	ownerId := habit.Owner
@@ -426,9 +512,25 @@ func (a *HabitShareFile) DeleteHabit(id string) error {
 		panic("Habit exists but owner does not")
 	}
 	delete(owner.MyHabits, id)
+	for _, member := range habit.Members {
+		if user, ok := a.Users[member]; ok {
+			delete(user.MyHabits, id)
+		}
+	}
 
 	delete(a.Habits, id)
 
+	for invitationId, invitation := range a.Invitations {
+		if invitation.HabitId == id {
+			delete(a.Invitations, invitationId)
+		}
+	}
+	for token, link := range a.PublicLinks {
+		if link.HabitId == id {
+			delete(a.PublicLinks, token)
+		}
+	}
+
 	err := a.write()
 	if err != nil {
 		return err
@@ -523,6 +625,25 @@ func (a *HabitShareFile) GetMyHabits(owner string, limit int, archived bool) ([]
 	return myHabits, nil
 }
 
+// GetUnarchivedHabits implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetUnarchivedHabits() ([]habit_share.Habit, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	habits := make([]habit_share.Habit, 0, len(a.Habits))
+	for _, habit := range a.Habits {
+		if !habit.Archived {
+			habits = append(habits, habit.Habit)
+		}
+	}
+
+	sort.Slice(habits, func(i, j int) bool {
+		return habits[i].Id < habits[j].Id
+	})
+	return habits, nil
+}
+
 // GetSharedHabits implements habit_share.HabitsDatabase
 func (a *HabitShareFile) GetSharedHabits(owner string, limit int) ([]habit_share.Habit, error) {
 	if err := a.read(); err != nil {
@@ -554,73 +675,3 @@ func (a *HabitShareFile) GetSharedHabits(owner string, limit int) ([]habit_share
 
 	return sharedHabits, nil
 }
-
-// GetStreak implements habit_share.HabitsDatabase
-// TODO for performance this should be calculated on activity entry and cached
-func (a *HabitShareFile) GetScore(habitId string) (int, error) {
-	if err := a.read(); err != nil {
-		return 0, err
-	}
-	habit, ok := a.Habits[habitId]
-	if !ok {
-		return 0, habit_share.HabitNotFoundError
-	}
-
-	// check frequency
-	// streak_counter for streak
-	// ignore current week and find last sunday (last sunday if sunday is today)
-	// start counting from that day till monday
-	// if count >= frequency increment streak_counter
-	// if count < frequency stop and return
-	// if i == 0 return
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-	totalScore := 0  // count for successes
-	weeklyCount := 0 // threshold for frequency (counts minimum and success)
-	// values outside the normal range are normalised day -1 goes to the previous month
-	// weekStart is at the END of Sunday. The first second of Monday hence the -1 == +6 mod 7
-	// start of this week
-	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
-
-	index := len(habit.Activities) - 1
-	// loop for current week doesn't matter what the score is this week assume it's part of the streak
-	for ; index >= 0; index-- {
-		if habit.Activities[index].Status == "NOT_DONE" {
-			continue
-		}
-
-		if habit.Activities[index].Logged.Before(weekStart) {
-			break
-		}
-		// assume this week is part of a streak
-		// find first activity that is before or on the startDate
-		// This is probably faster than binary search given distribution of requests
-		if habit.Activities[index].Status == "SUCCESS" {
-			totalScore++
-		}
-	}
-
-	weekStart = weekStart.AddDate(0, 0, -7)
-	for ; index >= 0; index-- {
-		// TODO don't store NOT_DONE just delete them
-		if habit.Activities[index].Status == "NOT_DONE" {
-			continue
-		}
-
-		if habit.Activities[index].Logged.Before(weekStart) {
-			// the final week (even if incomplete) is considered part of the streak
-			if weeklyCount < habit.Frequency {
-				return totalScore, nil
-			}
-			weeklyCount = 0
-			weekStart = weekStart.AddDate(0, 0, -7)
-		}
-
-		weeklyCount++
-		if habit.Activities[index].Status == "SUCCESS" {
-			totalScore++
-		}
-	}
-
-	return totalScore, nil
-}
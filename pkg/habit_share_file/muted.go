@@ -0,0 +1,56 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// MuteHabit implements habit_share.HabitsDatabase
+func (a *HabitShareFile) MuteHabit(userId string, habitId string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user, ok := a.Users[userId]
+	if !ok {
+		return habit_share.UserNotFoundError
+	}
+	if user.Muted == nil {
+		user.Muted = make(map[string]struct{})
+	}
+	user.Muted[habitId] = struct{}{}
+	a.Users[userId] = user
+
+	return a.write()
+}
+
+// UnmuteHabit implements habit_share.HabitsDatabase
+func (a *HabitShareFile) UnmuteHabit(userId string, habitId string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user, ok := a.Users[userId]
+	if !ok {
+		return habit_share.UserNotFoundError
+	}
+	delete(user.Muted, habitId)
+
+	return a.write()
+}
+
+// GetMutedHabits implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetMutedHabits(userId string) ([]string, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	muted := make([]string, 0, len(a.Users[userId].Muted))
+	for habitId := range a.Users[userId].Muted {
+		muted = append(muted, habitId)
+	}
+	sort.Strings(muted)
+
+	return muted, nil
+}
@@ -0,0 +1,81 @@
+package habit_share_file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestNotification(t *testing.T) {
+	t.Run("should list unread notifications newest first", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+
+		ids := make([]string, 0)
+		for _, habitId := range []string{"first", "second", "third"} {
+			id, err := habitShare.CreateNotification(habit_share.Notification{User: "user", HabitId: habitId})
+			if err != nil {
+				t.Fatal("expected no error got ", err)
+			}
+			ids = append(ids, id)
+		}
+		read, _ := habitShare.GetNotification("user", ids[2])
+		read.Read = true
+		if err := habitShare.SetNotification("user", read); err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+
+		notifications, err := habitShare.GetNotifications("user", 10, true)
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if len(notifications) != 2 || notifications[0].HabitId != "second" || notifications[1].HabitId != "first" {
+			t.Error("expected the unread notifications newest first got ", notifications)
+		}
+		if notifications, _ := habitShare.GetNotifications("user", 1, false); len(notifications) != 1 || notifications[0].HabitId != "third" {
+			t.Error("expected only the newest notification got ", notifications)
+		}
+	})
+
+	t.Run("should only delete the user's own notifications", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+		id, _ := habitShare.CreateNotification(habit_share.Notification{User: "user"})
+
+		if err := habitShare.DeleteNotification("other", id); err != habit_share.NotificationNotFoundError {
+			t.Error("expected NotificationNotFoundError got ", err)
+		}
+		if err := habitShare.DeleteNotification("user", id); err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if notifications, _ := habitShare.GetNotifications("user", 10, false); len(notifications) != 0 {
+			t.Error("expected no notifications got ", notifications)
+		}
+	})
+
+	t.Run("should list notifications created since", func(t *testing.T) {
+		habitShare := HabitShareFile{
+			Users:  map[string]User{},
+			Habits: map[string]HabitJson{},
+		}
+		now := time.Now()
+		for _, created := range []time.Time{now.Add(-48 * time.Hour), now.Add(-time.Hour), now} {
+			if _, err := habitShare.CreateNotification(habit_share.Notification{User: "user", Created: created}); err != nil {
+				t.Fatal("expected no error got ", err)
+			}
+		}
+
+		notifications, err := habitShare.GetNotificationsSince("user", now.Add(-24*time.Hour))
+		if err != nil {
+			t.Fatal("expected no error got ", err)
+		}
+		if len(notifications) != 2 || !notifications[0].Created.Equal(now) {
+			t.Error("expected the 2 latest notifications newest first got ", notifications)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package habit_share_file
+
+import (
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+
+	"github.com/google/uuid"
+)
+
+var _ habit_share.NotificationsDatabase = (*HabitShareFile)(nil)
+
+// CreateNotification implements habit_share.NotificationsDatabase
+func (a *HabitShareFile) CreateNotification(newNotification habit_share.Notification) (string, error) {
+	if err := a.read(); err != nil {
+		return "", err
+	}
+	newNotification.Id = uuid.NewString()
+	// notifications are created as they happen so appending keeps them sorted
+	a.Notifications[newNotification.User] = append(a.Notifications[newNotification.User], newNotification)
+
+	err := a.write()
+	if err != nil {
+		return newNotification.Id, err
+	}
+
+	return newNotification.Id, nil
+}
+
+func (a *HabitShareFile) notificationIndex(user string, id string) (int, error) {
+	for i, notification := range a.Notifications[user] {
+		if notification.Id == id {
+			return i, nil
+		}
+	}
+	return 0, habit_share.NotificationNotFoundError
+}
+
+// GetNotification implements habit_share.NotificationsDatabase
+func (a *HabitShareFile) GetNotification(user string, id string) (habit_share.Notification, error) {
+	if err := a.read(); err != nil {
+		return habit_share.Notification{}, err
+	}
+
+	i, err := a.notificationIndex(user, id)
+	if err != nil {
+		return habit_share.Notification{}, err
+	}
+	return a.Notifications[user][i], nil
+}
+
+// GetNotifications implements habit_share.NotificationsDatabase
+func (a *HabitShareFile) GetNotifications(user string, limit int, unread bool) ([]habit_share.Notification, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	stored := a.Notifications[user]
+	notifications := make([]habit_share.Notification, 0)
+	for i := len(stored) - 1; i >= 0 && len(notifications) < limit; i-- {
+		if unread && stored[i].Read {
+			continue
+		}
+		notifications = append(notifications, stored[i])
+	}
+
+	return notifications, nil
+}
+
+// GetNotificationsSince implements habit_share.NotificationsDatabase
+func (a *HabitShareFile) GetNotificationsSince(user string, since time.Time) ([]habit_share.Notification, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	stored := a.Notifications[user]
+	notifications := make([]habit_share.Notification, 0)
+	for i := len(stored) - 1; i >= 0 && stored[i].Created.After(since); i-- {
+		notifications = append(notifications, stored[i])
+	}
+
+	return notifications, nil
+}
+
+// SetNotification implements habit_share.NotificationsDatabase
+func (a *HabitShareFile) SetNotification(user string, updatedNotification habit_share.Notification) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	i, err := a.notificationIndex(user, updatedNotification.Id)
+	if err != nil {
+		return err
+	}
+	a.Notifications[user][i] = updatedNotification
+
+	return a.write()
+}
+
+// DeleteNotification implements habit_share.NotificationsDatabase
+func (a *HabitShareFile) DeleteNotification(user string, id string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	i, err := a.notificationIndex(user, id)
+	if err != nil {
+		return err
+	}
+	notifications := a.Notifications[user]
+	a.Notifications[user] = append(notifications[:i], notifications[i+1:]...)
+
+	return a.write()
+}
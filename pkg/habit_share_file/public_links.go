@@ -0,0 +1,64 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// CreatePublicLink implements habit_share.HabitsDatabase
+func (a *HabitShareFile) CreatePublicLink(newLink habit_share.PublicLink) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+	a.PublicLinks[newLink.Token] = newLink
+
+	return a.write()
+}
+
+// GetPublicLink implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetPublicLink(token string) (habit_share.PublicLink, error) {
+	if err := a.read(); err != nil {
+		return habit_share.PublicLink{}, err
+	}
+
+	link, ok := a.PublicLinks[token]
+	if !ok {
+		return habit_share.PublicLink{}, habit_share.PublicLinkNotFoundError
+	}
+	return link, nil
+}
+
+// GetPublicLinks implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetPublicLinks(habitId string) ([]habit_share.PublicLink, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	links := make([]habit_share.PublicLink, 0)
+	for _, link := range a.PublicLinks {
+		if link.HabitId == habitId {
+			links = append(links, link)
+		}
+	}
+
+	// map does not guarantee this is in order
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Created.Before(links[j].Created)
+	})
+	return links, nil
+}
+
+// DeletePublicLink implements habit_share.HabitsDatabase
+func (a *HabitShareFile) DeletePublicLink(token string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	if _, ok := a.PublicLinks[token]; !ok {
+		return habit_share.PublicLinkNotFoundError
+	}
+	delete(a.PublicLinks, token)
+
+	return a.write()
+}
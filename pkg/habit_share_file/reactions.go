@@ -0,0 +1,78 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// reactionsWithout returns the reactions that don't match, leaving reactions
+// alone
+func reactionsWithout(reactions []habit_share.Reaction, match func(habit_share.Reaction) bool) []habit_share.Reaction {
+	kept := make([]habit_share.Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		if !match(reaction) {
+			kept = append(kept, reaction)
+		}
+	}
+	return kept
+}
+
+// AddReaction implements habit_share.HabitsDatabase
+func (a *HabitShareFile) AddReaction(habitId string, reaction habit_share.Reaction) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+	habit, ok := a.Habits[habitId]
+	if !ok {
+		return habit_share.HabitNotFoundError
+	}
+
+	habit.Reactions = append(reactionsWithout(habit.Reactions, func(other habit_share.Reaction) bool {
+		return other.ActivityId == reaction.ActivityId && other.User == reaction.User
+	}), reaction)
+	sort.SliceStable(habit.Reactions, func(i, j int) bool {
+		return habit.Reactions[i].Created.Before(habit.Reactions[j].Created)
+	})
+	a.Habits[habitId] = habit
+
+	return a.write()
+}
+
+// GetReactions implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetReactions(habitId string) ([]habit_share.Reaction, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+	habit, ok := a.Habits[habitId]
+	if !ok {
+		return nil, habit_share.HabitNotFoundError
+	}
+
+	if habit.Reactions == nil {
+		return make([]habit_share.Reaction, 0), nil
+	}
+	return habit.Reactions, nil
+}
+
+// DeleteReaction implements habit_share.HabitsDatabase
+func (a *HabitShareFile) DeleteReaction(habitId string, activityId string, user string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+	habit, ok := a.Habits[habitId]
+	if !ok {
+		return habit_share.HabitNotFoundError
+	}
+
+	kept := reactionsWithout(habit.Reactions, func(reaction habit_share.Reaction) bool {
+		return reaction.ActivityId == activityId && reaction.User == user
+	})
+	if len(kept) == len(habit.Reactions) {
+		return habit_share.ReactionNotFoundError
+	}
+	habit.Reactions = kept
+	a.Habits[habitId] = habit
+
+	return a.write()
+}
@@ -0,0 +1,31 @@
+package habit_share_file
+
+import (
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+// GetUserSettings implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetUserSettings(user string) (habit_share.UserSettings, error) {
+	if err := a.read(); err != nil {
+		return habit_share.UserSettings{}, err
+	}
+
+	return a.Users[user].Settings, nil
+}
+
+// SetUserSettings implements habit_share.HabitsDatabase
+func (a *HabitShareFile) SetUserSettings(userId string, settings habit_share.UserSettings) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	user, ok := a.Users[userId]
+	if !ok {
+		// Create user. Validation if user should exist or not should be based on account manager
+		user = User{MyHabits: make(map[string]struct{}, 0), SharedHabits: make(map[string]struct{}, 0)}
+	}
+	user.Settings = settings
+	a.Users[userId] = user
+
+	return a.write()
+}
@@ -0,0 +1,40 @@
+package habit_share_file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+)
+
+func TestVacation(t *testing.T) {
+	t.Run("should create, list and delete vacations", func(t *testing.T) {
+		testUsers, testHabits := generateTestData()
+		habitShare := HabitShareFile{Users: testUsers, Habits: testHabits}
+
+		vacationId, err := habitShare.CreateVacation(habit_share.Vacation{
+			Owner: "testUser1",
+			Start: habit_share.Time{Time: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			End:   habit_share.Time{Time: time.Date(2022, time.January, 7, 0, 0, 0, 0, time.UTC)},
+		})
+		if err != nil {
+			t.Fatal("CreateVacation returned error unexpectedly:", err)
+		}
+
+		vacations, err := habitShare.GetVacations("testUser1")
+		if err != nil {
+			t.Fatal("GetVacations returned error unexpectedly:", err)
+		}
+		if len(vacations) != 1 || vacations[0].Id != vacationId {
+			t.Fatal("vacation was not stored got:", vacations)
+		}
+
+		err = habitShare.DeleteVacation("testUser1", vacationId)
+		if err != nil {
+			t.Fatal("DeleteVacation returned error unexpectedly:", err)
+		}
+		if err := habitShare.DeleteVacation("testUser1", vacationId); err != habit_share.VacationNotFoundError {
+			t.Fatal("expected vacation not found got:", err)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package habit_share_file
+
+import (
+	"sort"
+
+	"github.com/Joshua-Hwang/habits2share/pkg/habit_share"
+
+	"github.com/google/uuid"
+)
+
+// CreateVacation implements habit_share.HabitsDatabase
+func (a *HabitShareFile) CreateVacation(newVacation habit_share.Vacation) (string, error) {
+	if err := a.read(); err != nil {
+		return "", err
+	}
+	newVacation.Id = uuid.NewString()
+	vacations := append(a.Vacations[newVacation.Owner], newVacation)
+	sort.Slice(vacations, func(i, j int) bool {
+		return vacations[i].Start.Before(vacations[j].Start.Time)
+	})
+	a.Vacations[newVacation.Owner] = vacations
+
+	err := a.write()
+	if err != nil {
+		return newVacation.Id, err
+	}
+
+	return newVacation.Id, nil
+}
+
+// GetVacations implements habit_share.HabitsDatabase
+func (a *HabitShareFile) GetVacations(owner string) ([]habit_share.Vacation, error) {
+	if err := a.read(); err != nil {
+		return nil, err
+	}
+
+	vacations, ok := a.Vacations[owner]
+	if !ok {
+		return make([]habit_share.Vacation, 0), nil
+	}
+	return vacations, nil
+}
+
+// DeleteVacation implements habit_share.HabitsDatabase
+func (a *HabitShareFile) DeleteVacation(owner string, id string) error {
+	if err := a.read(); err != nil {
+		return err
+	}
+
+	vacations := a.Vacations[owner]
+	for i, vacation := range vacations {
+		if vacation.Id == id {
+			// copy so the slice previously returned by GetVacations isn't modified
+			remaining := make([]habit_share.Vacation, 0, len(vacations)-1)
+			remaining = append(remaining, vacations[:i]...)
+			remaining = append(remaining, vacations[i+1:]...)
+			a.Vacations[owner] = remaining
+
+			return a.write()
+		}
+	}
+
+	return habit_share.VacationNotFoundError
+}
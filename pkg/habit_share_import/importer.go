@@ -65,7 +65,7 @@ func importCsv(db habit_share.HabitsDatabase, owner string, csvReader *csv.Reade
 			nameToHabitId[row[0]] = habitId
 		}
 		habitId := nameToHabitId[row[0]]
-		db.CreateActivity(habitId, activityDate, status)
+		db.CreateActivity(habit_share.Activity{HabitId: habitId, Logged: activityDate, Status: status})
 	}
 
 	v := make([]string, 0, len(nameToHabitId))
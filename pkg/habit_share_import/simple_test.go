@@ -29,7 +29,7 @@ Reading,2021-07-26,skip,`))
 			func(habit habit_share.Habit) (string, error) {
 				return habit.Name, nil
 			})
-		db.EXPECT().CreateActivity(gomock.Any(), gomock.Any(), gomock.Any()).Times(9).Return("useless id", nil)
+		db.EXPECT().CreateActivity(gomock.Any()).Times(9).Return("useless id", nil)
 
 		_, err := importCsv(db, "testUser", csvReader)
 		if err != nil {
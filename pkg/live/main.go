@@ -0,0 +1,161 @@
+package live
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+)
+
+// how many events a subscriber can fall behind before it's dropped. Dropped
+// subscribers catch up by reconnecting with the last event they got.
+const subscriptionBuffer = 64
+
+// Event is a change pushed to the Users it concerns
+type Event struct {
+	// increasing from 1 each time the server starts
+	Id    uint64
+	Type  string
+	Users []string
+	// JSON
+	Data []byte
+}
+
+func (e Event) concerns(user string) bool {
+	for _, u := range e.Users {
+		if u == user {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription receives the events for User until it's unsubscribed or falls
+// too far behind, either of which closes Events
+type Subscription struct {
+	User   string
+	Events <-chan Event
+	events chan Event
+}
+
+// Broker is an in process pub/sub that keeps the latest events so subscribers
+// that reconnect can be sent what they missed. A nil Broker publishes nothing.
+type Broker struct {
+	mu     sync.Mutex
+	lastId uint64
+	// the latest events oldest first
+	buffer      []Event
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+}
+
+// NewBroker keeps the last bufferSize events for replaying
+func NewBroker(bufferSize int) *Broker {
+	return &Broker{
+		buffer:      make([]Event, 0, bufferSize),
+		bufferSize:  bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends data as JSON to the subscriptions of users
+func (b *Broker) Publish(users []string, eventType string, data interface{}) {
+	if b == nil || len(users) == 0 {
+		return
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to encode %s event: %v", eventType, err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastId++
+	event := Event{Id: b.lastId, Type: eventType, Users: users, Data: encoded}
+	if len(b.buffer) == b.bufferSize && b.bufferSize > 0 {
+		b.buffer = append(b.buffer[:0], b.buffer[1:]...)
+	}
+	if b.bufferSize > 0 {
+		b.buffer = append(b.buffer, event)
+	}
+
+	for subscription := range b.subscribers {
+		if !event.concerns(subscription.User) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			// too far behind, it's up to them to reconnect
+			b.remove(subscription)
+		}
+	}
+}
+
+// Subscribe starts sending user's events. Those after lastEventId that are
+// still kept are returned to be sent first. complete is false when some were
+// no longer kept, or lastEventId is from before the server started, and
+// everything should be fetched again. A lastEventId of 0 replays nothing.
+func (b *Broker) Subscribe(user string, lastEventId uint64) (subscription *Subscription, replay []Event, complete bool) {
+	events := make(chan Event, subscriptionBuffer)
+	subscription = &Subscription{User: user, Events: events, events: events}
+	if b == nil {
+		return subscription, nil, lastEventId == 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[subscription] = struct{}{}
+	if lastEventId == 0 {
+		return subscription, nil, true
+	}
+
+	oldest := b.lastId + 1
+	if len(b.buffer) > 0 {
+		oldest = b.buffer[0].Id
+	}
+	complete = lastEventId <= b.lastId && lastEventId+1 >= oldest
+	if !complete {
+		return subscription, nil, false
+	}
+
+	for _, event := range b.buffer {
+		if event.Id > lastEventId && event.concerns(user) {
+			replay = append(replay, event)
+		}
+	}
+	return subscription, replay, true
+}
+
+// LastId is the Id of the latest event published
+func (b *Broker) LastId() uint64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.lastId
+}
+
+// Unsubscribe stops sending events to the subscription
+func (b *Broker) Unsubscribe(subscription *Subscription) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(subscription)
+}
+
+// remove must be called with mu held
+func (b *Broker) remove(subscription *Subscription) {
+	if _, ok := b.subscribers[subscription]; !ok {
+		return
+	}
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+}
@@ -0,0 +1,76 @@
+package live
+
+import "testing"
+
+func TestBroker(t *testing.T) {
+	t.Run("should only send events to the users they concern", func(t *testing.T) {
+		broker := NewBroker(10)
+		mine, _, _ := broker.Subscribe("me", 0)
+		theirs, _, _ := broker.Subscribe("them", 0)
+
+		broker.Publish([]string{"me"}, "CHANGED", map[string]string{"Id": "1"})
+
+		select {
+		case event := <-mine.Events:
+			if event.Id != 1 || event.Type != "CHANGED" || string(event.Data) != `{"Id":"1"}` {
+				t.Errorf("unexpected event %+v", event)
+			}
+		default:
+			t.Error("expected an event")
+		}
+		select {
+		case event := <-theirs.Events:
+			t.Errorf("expected no event got %+v", event)
+		default:
+		}
+	})
+
+	t.Run("should replay what was missed", func(t *testing.T) {
+		broker := NewBroker(10)
+		for i := 0; i < 3; i++ {
+			broker.Publish([]string{"me"}, "CHANGED", i)
+		}
+		broker.Publish([]string{"them"}, "CHANGED", 3)
+
+		_, replay, complete := broker.Subscribe("me", 1)
+		if !complete || len(replay) != 2 || replay[0].Id != 2 || replay[1].Id != 3 {
+			t.Errorf("expected events 2 and 3 got %v %+v", complete, replay)
+		}
+	})
+
+	t.Run("should ask for a resync when the buffer moved on", func(t *testing.T) {
+		broker := NewBroker(2)
+		for i := 0; i < 4; i++ {
+			broker.Publish([]string{"me"}, "CHANGED", i)
+		}
+
+		if _, replay, complete := broker.Subscribe("me", 1); complete || len(replay) != 0 {
+			t.Errorf("expected an incomplete replay got %v %+v", complete, replay)
+		}
+		if _, replay, complete := broker.Subscribe("me", 2); !complete || len(replay) != 2 {
+			t.Errorf("expected the 2 kept events got %v %+v", complete, replay)
+		}
+		// from before the server restarted
+		if _, _, complete := broker.Subscribe("me", 100); complete {
+			t.Error("expected an incomplete replay")
+		}
+	})
+
+	t.Run("should drop subscribers that fall behind", func(t *testing.T) {
+		broker := NewBroker(0)
+		subscription, _, _ := broker.Subscribe("me", 0)
+		for i := 0; i <= subscriptionBuffer; i++ {
+			broker.Publish([]string{"me"}, "CHANGED", i)
+		}
+
+		received := 0
+		for range subscription.Events {
+			received++
+		}
+		if received != subscriptionBuffer {
+			t.Errorf("expected %d events before closing got %d", subscriptionBuffer, received)
+		}
+		// already dropped so nothing happens
+		broker.Unsubscribe(subscription)
+	})
+}
@@ -26,6 +26,19 @@ type AuthInterface interface {
 	GetCurrentUser() (string, error)
 }
 
+// the todo was created or changed
+const LiveTodoChanged = "TODO_CHANGED"
+
+// LiveInterface pushes changes to users as they happen
+type LiveInterface interface {
+	Publish(users []string, eventType string, data interface{})
+}
+
+// LiveChange is what gets pushed, clients fetch the todo themselves
+type LiveChange struct {
+	TodoId string
+}
+
 type Todo struct {
 	Id          string
 	Owner       string
@@ -48,6 +61,21 @@ type TodoDatabase interface {
 type App struct {
 	Db   TodoDatabase
 	Auth AuthInterface
+	// optional, without it changes aren't pushed to anyone
+	Live LiveInterface
+}
+
+// publish tells the current user, who owns every todo they can change, about
+// the change
+func (a *App) publish(todoId string) {
+	if a.Live == nil {
+		return
+	}
+	user, err := a.Auth.GetCurrentUser()
+	if err != nil {
+		return
+	}
+	a.Live.Publish([]string{user}, LiveTodoChanged, LiveChange{TodoId: todoId})
 }
 
 func (a *App) ownerCheck(todoId string) error {
@@ -75,7 +103,12 @@ func (a *App) CreateTodo(name string, dueDate time.Time) (string, error) {
 		return "", err
 	}
 
-	return a.Db.CreateTodo(name, user, dueDate)
+	id, err := a.Db.CreateTodo(name, user, dueDate)
+	if err != nil {
+		return id, err
+	}
+	a.publish(id)
+	return id, nil
 }
 
 func (a *App) ChangeName(todoId string, newName string) error {
@@ -83,7 +116,11 @@ func (a *App) ChangeName(todoId string, newName string) error {
 		return err
 	}
 
-	return a.Db.ChangeName(todoId, newName)
+	if err := a.Db.ChangeName(todoId, newName); err != nil {
+		return err
+	}
+	a.publish(todoId)
+	return nil
 }
 
 func (a *App) ChangeDescription(todoId string, newDescription string) error {
@@ -91,7 +128,11 @@ func (a *App) ChangeDescription(todoId string, newDescription string) error {
 		return err
 	}
 
-	return a.Db.ChangeDescription(todoId, newDescription)
+	if err := a.Db.ChangeDescription(todoId, newDescription); err != nil {
+		return err
+	}
+	a.publish(todoId)
+	return nil
 }
 
 func (a *App) ChangeDueDate(todoId string, newTime time.Time) error {
@@ -99,7 +140,11 @@ func (a *App) ChangeDueDate(todoId string, newTime time.Time) error {
 		return err
 	}
 
-	return a.Db.ChangeDueDate(todoId, newTime)
+	if err := a.Db.ChangeDueDate(todoId, newTime); err != nil {
+		return err
+	}
+	a.publish(todoId)
+	return nil
 }
 
 func (a *App) CompleteTodo(todoId string) error {
@@ -107,7 +152,11 @@ func (a *App) CompleteTodo(todoId string) error {
 		return err
 	}
 
-	return a.Db.CompleteTodo(todoId)
+	if err := a.Db.CompleteTodo(todoId); err != nil {
+		return err
+	}
+	a.publish(todoId)
+	return nil
 }
 
 func (a *App) GetMyTodos(limit int, completed bool) ([]Todo, error) {
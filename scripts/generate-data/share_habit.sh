@@ -1,10 +1,17 @@
 #!/bin/bash
 
 tmp_file1=$(mktemp)
+tmp_file2=$(mktemp)
 
 #echo $tmp_file1
 
 curl -F email=test1@mail.com -c $tmp_file1 localhost:8080/login
+curl -F email=test2@mail.com -c $tmp_file2 localhost:8080/login
+
+# habits can only be shared with friends
+curl -b $tmp_file1 --json "{\"To\":\"testAccount2\"}" localhost:8080/my/friend-requests
+curl -b $tmp_file2 -X POST localhost:8080/my/friend-requests/testAccount1/accept
+
 habit_id=$(curl -b $tmp_file1 --json "{\"Name\":\"shared\", \"Description\": \"sharing with test2\", \"Frequency\": 3}" localhost:8080/my/habits)
 
 curl -b $tmp_file1 -X POST localhost:8080/user/testAccount2/habit/$habit_id
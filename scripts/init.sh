@@ -11,6 +11,7 @@ SESSIONS_FILE=$dir/sessions.csv
 ACCOUNTS_FILE=$dir/accounts.json
 HABITS_FILE=$dir/habits.json
 TODO_FILE=$dir/todo.json
+FRIENDS_FILE=$dir/friends.json
 GOFLAGS=-tags=dev
 EOF
 
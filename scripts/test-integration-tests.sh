@@ -4,6 +4,7 @@ export SESSIONS_FILE=secrets_integration/sessions.csv
 export ACCOUNTS_FILE=secrets_integration/accounts.json
 export HABITS_FILE=secrets_integration/habits.json
 export TODO_FILE=secrets_integration/todo.json
+export FRIENDS_FILE=secrets_integration/friends.json
 export GOFLAGS=-tags=dev
 
 #./scripts/build-frontend.sh || exit $?